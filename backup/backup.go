@@ -0,0 +1,135 @@
+// Package backup saves a TickTick account to a JSON archive and restores it.
+//
+// # Archive format
+//
+// An archive is a single JSON document:
+//
+//	{
+//	  "version": 1,
+//	  "createdAt": "2024-01-15T09:00:00Z",
+//	  "projects": [
+//	    {
+//	      "project": { "id": "...", "name": "Work", "color": "#F18181", ... },
+//	      "columns": [ { "id": "...", "name": "To Do", "sortOrder": 0, ... } ],
+//	      "tasks": [ { "id": "...", "title": "...", "items": [ ... ], ... } ]
+//	    }
+//	  ]
+//	}
+//
+// Projects, columns and tasks use the JSON representation of the Open API,
+// as produced by [ticktick.Project], [ticktick.Column] and [ticktick.Task],
+// including members those types do not model. Checklist items are stored in
+// their task's "items". The Inbox is stored like a project whose ID starts
+// with "inbox" (see [ticktick.IsInboxID]).
+//
+// The same data can be written to and read from the CSV layout of TickTick's
+// own backups with [WriteCSV] and [ReadCSV]. That layout has a single Content
+// column, which holds the description of checklist tasks and the content of
+// other tasks, so the description of a task that is not a checklist is lost.
+//
+// The version is incremented whenever the format changes incompatibly; [Read]
+// rejects archives of newer versions. Only open tasks are archived, because
+// the Open API does not list completed ones.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// Version is the archive format version written by [Backup].
+const Version = 1
+
+// ErrUnsupportedVersion is returned by [Read] for archives written by a newer
+// version of this package.
+var ErrUnsupportedVersion = errors.New("backup: unsupported archive version")
+
+// Archive is the content of a backup.
+type Archive struct {
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Projects  []ProjectBackup `json:"projects"`
+}
+
+// ProjectBackup holds one project with its columns and tasks.
+type ProjectBackup struct {
+	Project ticktick.Project  `json:"project"`
+	Columns []ticktick.Column `json:"columns"`
+	Tasks   []ticktick.Task   `json:"tasks"`
+	// Folder is the name of the project's folder. The Open API does not expose
+	// folder names, so [Backup] leaves it empty; [ReadCSV] fills it and
+	// [WriteCSV] writes it.
+	Folder string `json:"folder,omitempty"`
+}
+
+// Backup writes an archive of every project, including closed ones and the
+// Inbox, to w.
+func Backup(ctx context.Context, client *ticktick.Client, w io.Writer) error {
+	archive, err := Snapshot(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(archive); err != nil {
+		return fmt.Errorf("backup: write archive: %w", err)
+	}
+
+	return nil
+}
+
+// Snapshot fetches the archive that [Backup] would write.
+func Snapshot(ctx context.Context, client *ticktick.Client) (*Archive, error) {
+	projects, err := client.GetProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("backup: list projects: %w", err)
+	}
+
+	archive := &Archive{Version: Version, CreatedAt: time.Now().UTC()}
+
+	for _, p := range projects {
+		data, err := client.GetProjectData(ctx, p.ID)
+		if err != nil {
+			return nil, fmt.Errorf("backup: read project %s: %w", p.ID, err)
+		}
+
+		// The project list is authoritative for project settings.
+		archive.Projects = append(archive.Projects, ProjectBackup{Project: p, Columns: data.Columns, Tasks: data.Tasks})
+	}
+
+	inbox, err := client.Inbox(ctx)
+	if errors.Is(err, ticktick.ErrInboxUnsupported) {
+		return archive, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("backup: read inbox: %w", err)
+	}
+
+	archive.Projects = append(archive.Projects, ProjectBackup{Project: inbox.Project, Tasks: inbox.Tasks})
+
+	return archive, nil
+}
+
+// Read decodes an archive written by [Backup].
+func Read(r io.Reader) (*Archive, error) {
+	var archive Archive
+
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("backup: read archive: %w", err)
+	}
+
+	if archive.Version < 1 || archive.Version > Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, archive.Version)
+	}
+
+	return &archive, nil
+}
@@ -0,0 +1,73 @@
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/backup"
+)
+
+func TestBackup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/open/v1/project":
+			json.NewEncoder(w).Encode([]ticktick.Project{{ID: "p1", Name: "Work", Color: "#F18181"}})
+		case "/open/v1/project/p1/data":
+			w.Write([]byte(`{
+				"project": {"id": "p1"},
+				"columns": [{"id": "c1", "projectId": "p1", "name": "To Do"}],
+				"tasks": [{"id": "t1", "projectId": "p1", "title": "Task", "etag": "abc",
+					"items": [{"id": "i1", "title": "Item"}]}]
+			}`))
+		case "/open/v1/project/inbox/data":
+			json.NewEncoder(w).Encode(ticktick.ProjectData{Tasks: []ticktick.Task{{ID: "t2", ProjectID: "inbox42"}}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := ticktick.NewClient("token", ticktick.WithBaseURL(server.URL))
+
+	var buf bytes.Buffer
+	if err := backup.Backup(context.Background(), client, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"etag": "abc"`) {
+		t.Errorf("expected unmodeled members to be kept:\n%s", buf.String())
+	}
+
+	archive, err := backup.Read(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if archive.Version != backup.Version || len(archive.Projects) != 2 {
+		t.Fatalf("unexpected archive: %+v", archive)
+	}
+
+	work := archive.Projects[0]
+	if work.Project.Color != "#F18181" || len(work.Columns) != 1 || len(work.Tasks[0].Items) != 1 {
+		t.Errorf("unexpected project backup: %+v", work)
+	}
+
+	if inbox := archive.Projects[1]; inbox.Project.ID != "inbox42" || len(inbox.Tasks) != 1 {
+		t.Errorf("unexpected inbox backup: %+v", inbox)
+	}
+}
+
+func TestReadVersion(t *testing.T) {
+	for _, data := range []string{`{"version": 99}`, `{"projects": []}`} {
+		if _, err := backup.Read(strings.NewReader(data)); !errors.Is(err, backup.ErrUnsupportedVersion) {
+			t.Errorf("%s: expected ErrUnsupportedVersion, got %v", data, err)
+		}
+	}
+}
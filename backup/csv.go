@@ -0,0 +1,399 @@
+package backup
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// CSV status values, as listed in the preamble of TickTick's CSV backups.
+const (
+	csvStatusNormal    = "0"
+	csvStatusCompleted = "1"
+	csvStatusArchived  = "2"
+)
+
+// Checklist items are stored in the Content column, one per line, prefixed
+// with one of these markers.
+const (
+	csvItemOpen = "▫"
+	csvItemDone = "▪"
+)
+
+const (
+	csvTimeLayout = "2006-01-02T15:04:05-0700"
+	csvVersion    = "7.1"
+	// byteOrderMark may precede the header of files saved by spreadsheet applications.
+	byteOrderMark = "\ufeff"
+)
+
+// CSVHeader is the header row of TickTick's CSV backup.
+func CSVHeader() []string {
+	return []string{
+		"Folder Name", "List Name", "Title", "Kind", "Tags", "Content", "Is Check list", "Start Date",
+		"Due Date", "Reminder", "Repeat", "Priority", "Status", "Created Time", "Completed Time", "Order",
+		"Timezone", "Is All Day", "Is Floating", "Column Name", "Column Order", "View Mode", "taskId",
+		"parentId",
+	}
+}
+
+// WriteCSV writes the archive in the layout of the CSV backups produced by
+// TickTick's web app (Settings > Account > Backup), one row per task. The
+// Folder Name column comes from [ProjectBackup.Folder]. Checklist items are
+// written into the Content column after the description, one per line,
+// marked "▫" when open and "▪" when completed. Other tasks have their content
+// in that column; their Desc is not written.
+func WriteCSV(w io.Writer, archive *Archive) error {
+	cw := csv.NewWriter(w)
+
+	created := archive.CreatedAt
+	if created.IsZero() {
+		created = time.Now()
+	}
+
+	preamble := [][]string{
+		{"Date: " + created.UTC().Format("2006-01-02-0700")},
+		{"Version: " + csvVersion},
+		{"Status: \n" + csvStatusNormal + " Normal\n" + csvStatusCompleted + " Completed\n" +
+			csvStatusArchived + " Archived"},
+		CSVHeader(),
+	}
+
+	if err := cw.WriteAll(preamble); err != nil {
+		return fmt.Errorf("backup: write csv: %w", err)
+	}
+
+	for i := range archive.Projects {
+		pb := &archive.Projects[i]
+
+		for j := range pb.Tasks {
+			if err := cw.Write(csvRow(pb, &pb.Tasks[j])); err != nil {
+				return fmt.Errorf("backup: write csv: %w", err)
+			}
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("backup: write csv: %w", err)
+	}
+
+	return nil
+}
+
+// ReadCSV reads a CSV backup in TickTick's layout into an archive. Columns
+// are located by their header, so column order does not matter and missing
+// columns are left empty. Projects are identified by List Name; the one named
+// "Inbox" becomes the Inbox. Kanban columns are identified by name.
+func ReadCSV(r io.Reader) (*Archive, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	index, err := readCSVHeader(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &Archive{Version: Version, CreatedAt: time.Now().UTC()}
+	projects := make(map[string]int)
+
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("backup: read csv: %w", err)
+		}
+
+		row := csvRecord{index: index, values: record}
+
+		name := row.get("List Name")
+
+		i, ok := projects[name]
+		if !ok {
+			i = len(archive.Projects)
+			projects[name] = i
+			archive.Projects = append(archive.Projects, csvProject(row))
+		}
+
+		task, err := row.task(archive.Projects[i].Project.ID, line)
+		if err != nil {
+			return nil, err
+		}
+
+		addCSVColumn(&archive.Projects[i], row, &task)
+		archive.Projects[i].Tasks = append(archive.Projects[i].Tasks, task)
+	}
+
+	return archive, nil
+}
+
+func csvRow(pb *ProjectBackup, task *ticktick.Task) []string {
+	var column ticktick.Column
+
+	for _, col := range pb.Columns {
+		if col.ID == task.ColumnID {
+			column = col
+		}
+	}
+
+	content := task.Content
+	isChecklist := task.Kind == ticktick.TaskKindChecklist
+
+	if isChecklist {
+		lines := []string{}
+		if task.Desc != "" {
+			lines = append(lines, task.Desc)
+		}
+
+		for _, item := range task.Items {
+			marker := csvItemOpen
+			if item.Status == ticktick.ChecklistStatusCompleted {
+				marker = csvItemDone
+			}
+
+			lines = append(lines, marker+item.Title)
+		}
+
+		content = strings.Join(lines, "\n")
+	}
+
+	status := csvStatusNormal
+	if task.Status == ticktick.TaskStatusCompleted {
+		status = csvStatusCompleted
+	}
+
+	var createdTime ticktick.Time
+	if raw, ok := task.Extra["createdTime"]; ok {
+		_ = json.Unmarshal(raw, &createdTime)
+	}
+
+	columnOrder := ""
+	if column.ID != "" {
+		columnOrder = strconv.FormatInt(column.SortOrder, 10)
+	}
+
+	return []string{
+		pb.Folder, pb.Project.Name, task.Title, task.Kind, strings.Join(task.Tags, ","), content,
+		yesNo(isChecklist), csvTime(task.StartDate), csvTime(task.DueDate), strings.Join(task.Reminders, ","),
+		task.RepeatFlag, strconv.Itoa(task.Priority), status, csvTime(createdTime), csvTime(task.CompletedTime),
+		strconv.FormatInt(task.SortOrder, 10), task.TimeZone, strconv.FormatBool(task.IsAllDay), "false",
+		column.Name, columnOrder, pb.Project.ViewMode, task.ID, task.ParentID,
+	}
+}
+
+// readCSVHeader skips the preamble and returns the position of each column.
+func readCSVHeader(cr *csv.Reader) (map[string]int, error) {
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("backup: read csv: no header row")
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("backup: read csv: %w", err)
+		}
+
+		if len(record) == 0 || strings.TrimPrefix(record[0], byteOrderMark) != "Folder Name" {
+			continue
+		}
+
+		index := make(map[string]int, len(record))
+		for i, name := range record {
+			index[strings.TrimPrefix(name, byteOrderMark)] = i
+		}
+
+		return index, nil
+	}
+}
+
+func csvProject(row csvRecord) ProjectBackup {
+	name := row.get("List Name")
+
+	id := "list:" + name
+	if name == "Inbox" {
+		id = ticktick.InboxProjectID
+	}
+
+	return ProjectBackup{
+		Project: ticktick.Project{ID: id, Name: name, ViewMode: row.get("View Mode")},
+		Folder:  row.get("Folder Name"),
+	}
+}
+
+// addCSVColumn assigns the task to the kanban column named in the row,
+// adding the column to the project on first use.
+func addCSVColumn(pb *ProjectBackup, row csvRecord, task *ticktick.Task) {
+	name := row.get("Column Name")
+	if name == "" {
+		return
+	}
+
+	task.ColumnID = "column:" + name
+
+	for _, col := range pb.Columns {
+		if col.ID == task.ColumnID {
+			return
+		}
+	}
+
+	order, _ := strconv.ParseInt(row.get("Column Order"), 10, 64)
+	pb.Columns = append(pb.Columns, ticktick.Column{
+		ID:        task.ColumnID,
+		ProjectID: pb.Project.ID,
+		Name:      name,
+		SortOrder: order,
+	})
+}
+
+type csvRecord struct {
+	index  map[string]int
+	values []string
+}
+
+func (r csvRecord) get(column string) string {
+	i, ok := r.index[column]
+	if !ok || i >= len(r.values) {
+		return ""
+	}
+
+	return r.values[i]
+}
+
+func (r csvRecord) task(projectID string, line int) (ticktick.Task, error) {
+	task := ticktick.Task{
+		ID:         r.get("taskId"),
+		ProjectID:  projectID,
+		Title:      r.get("Title"),
+		Kind:       r.get("Kind"),
+		RepeatFlag: r.get("Repeat"),
+		TimeZone:   r.get("Timezone"),
+		ParentID:   r.get("parentId"),
+		IsAllDay:   r.get("Is All Day") == "true",
+		Tags:       splitList(r.get("Tags")),
+		Reminders:  splitList(r.get("Reminder")),
+	}
+
+	if task.ID == "" {
+		task.ID = "row:" + strconv.Itoa(line)
+	}
+
+	var err error
+
+	task.Priority, _ = strconv.Atoi(r.get("Priority"))
+	task.SortOrder, _ = strconv.ParseInt(r.get("Order"), 10, 64)
+
+	if r.get("Status") == csvStatusCompleted || r.get("Status") == csvStatusArchived {
+		task.Status = ticktick.TaskStatusCompleted
+	}
+
+	for _, f := range []struct {
+		column string
+		dst    *ticktick.Time
+	}{
+		{"Start Date", &task.StartDate},
+		{"Due Date", &task.DueDate},
+		{"Completed Time", &task.CompletedTime},
+	} {
+		if *f.dst, err = parseCSVTime(r.get(f.column), task.TimeZone); err != nil {
+			return task, fmt.Errorf("backup: read csv: row %d: %s: %w", line, f.column, err)
+		}
+	}
+
+	if r.get("Is Check list") == "Y" || task.Kind == ticktick.TaskKindChecklist {
+		task.Kind = ticktick.TaskKindChecklist
+		task.Desc, task.Items = parseCSVChecklist(r.get("Content"))
+	} else {
+		task.Content = r.get("Content")
+	}
+
+	return task, nil
+}
+
+// parseCSVChecklist splits checklist content into its description and items.
+func parseCSVChecklist(content string) (string, []ticktick.ChecklistItem) {
+	var (
+		desc  []string
+		items []ticktick.ChecklistItem
+	)
+
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, "\r\n")
+
+		if title, ok := strings.CutPrefix(line, csvItemOpen); ok {
+			items = append(items, ticktick.ChecklistItem{Title: title, Status: ticktick.ChecklistStatusNormal})
+		} else if title, ok := strings.CutPrefix(line, csvItemDone); ok {
+			items = append(items, ticktick.ChecklistItem{Title: title, Status: ticktick.ChecklistStatusCompleted})
+		} else {
+			desc = append(desc, line)
+		}
+	}
+
+	return strings.Join(desc, "\n"), items
+}
+
+func parseCSVTime(s, timeZone string) (ticktick.Time, error) {
+	if s == "" {
+		return ticktick.Time{}, nil
+	}
+
+	var (
+		t   time.Time
+		err error
+	)
+
+	for _, layout := range []string{csvTimeLayout, "2006-01-02T15:04:05.000-0700", time.RFC3339} {
+		if t, err = time.Parse(layout, s); err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return ticktick.Time{}, fmt.Errorf("invalid time %q", s)
+	}
+
+	if loc, err := time.LoadLocation(timeZone); err == nil && timeZone != "" {
+		t = t.In(loc)
+	}
+
+	return ticktick.Time{Time: t}, nil
+}
+
+func csvTime(t ticktick.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(csvTimeLayout)
+}
+
+func splitList(s string) []string {
+	var list []string
+
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Y"
+	}
+
+	return "N"
+}
@@ -0,0 +1,167 @@
+package backup_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/backup"
+)
+
+func TestWriteCSV(t *testing.T) {
+	due := ticktick.Time{Time: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)}
+	archive := &backup.Archive{
+		Version: backup.Version,
+		Projects: []backup.ProjectBackup{{
+			Folder:  "Office",
+			Project: ticktick.Project{ID: "p1", Name: "Work", ViewMode: ticktick.ViewModeKanban},
+			Columns: []ticktick.Column{{ID: "c1", Name: "Doing", SortOrder: 7}},
+			Tasks: []ticktick.Task{{
+				ID:       "t1",
+				Title:    "Release",
+				Kind:     ticktick.TaskKindChecklist,
+				Desc:     "Steps",
+				DueDate:  due,
+				Priority: ticktick.PriorityHigh,
+				Tags:     []string{"a", "b"},
+				ColumnID: "c1",
+				Items: []ticktick.ChecklistItem{
+					{Title: "Build", Status: ticktick.ChecklistStatusCompleted},
+					{Title: "Ship"},
+				},
+			}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := backup.WriteCSV(&buf, archive); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cr := csv.NewReader(&buf)
+	cr.FieldsPerRecord = -1
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(records) != 5 || !strings.HasPrefix(records[0][0], "Date: ") || records[3][0] != "Folder Name" {
+		t.Fatalf("unexpected layout: %q", records)
+	}
+
+	row := make(map[string]string)
+	for i, name := range backup.CSVHeader() {
+		row[name] = records[4][i]
+	}
+
+	want := map[string]string{
+		"Folder Name":   "Office",
+		"List Name":     "Work",
+		"Title":         "Release",
+		"Tags":          "a,b",
+		"Content":       "Steps\n▪Build\n▫Ship",
+		"Is Check list": "Y",
+		"Due Date":      "2024-01-15T09:00:00+0000",
+		"Priority":      "5",
+		"Status":        "0",
+		"Column Name":   "Doing",
+		"Column Order":  "7",
+		"View Mode":     "kanban",
+		"taskId":        "t1",
+	}
+
+	for column, value := range want {
+		if row[column] != value {
+			t.Errorf("%s: expected %q, got %q", column, value, row[column])
+		}
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	const data = "\ufeff\"Date: 2024-01-15+0000\"\n" +
+		"\"Version: 7.1\"\n" +
+		"\"Status: \n0 Normal\n1 Completed\n2 Archived\"\n" +
+		"\"Folder Name\",\"List Name\",\"Title\",\"Is Check list\",\"Content\",\"Due Date\",\"Timezone\"," +
+		"\"Status\",\"Column Name\",\"taskId\",\"Tags\"\n" +
+		"\"Office\",\"Work\",\"Release\",\"Y\",\"Steps\n▪Build\n▫Ship\",\"2024-01-15T09:00:00+0000\"," +
+		"\"Europe/Berlin\",\"1\",\"Doing\",\"t1\",\"a, b\"\n" +
+		"\"\",\"Inbox\",\"Call Bob\",\"N\",\"Number in contacts\",\"\",\"\",\"0\",\"\",\"\",\"\"\n"
+
+	archive, err := backup.ReadCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(archive.Projects) != 2 {
+		t.Fatalf("expected 2 projects, got %d", len(archive.Projects))
+	}
+
+	work := archive.Projects[0]
+	if work.Folder != "Office" || work.Project.Name != "Work" || len(work.Columns) != 1 {
+		t.Errorf("unexpected project: %+v", work)
+	}
+
+	task := work.Tasks[0]
+
+	if task.Kind != ticktick.TaskKindChecklist || task.Desc != "Steps" || len(task.Items) != 2 ||
+		task.Items[0].Status != ticktick.ChecklistStatusCompleted || task.Items[1].Title != "Ship" {
+		t.Errorf("unexpected checklist: %+v", task)
+	}
+
+	if task.Status != ticktick.TaskStatusCompleted || task.ColumnID != work.Columns[0].ID {
+		t.Errorf("unexpected status or column: %+v", task)
+	}
+
+	if task.DueDate.Location().String() != "Europe/Berlin" || task.DueDate.Hour() != 10 {
+		t.Errorf("expected due date in Europe/Berlin, got %v", task.DueDate)
+	}
+
+	if len(task.Tags) != 2 || task.Tags[1] != "b" {
+		t.Errorf("unexpected tags: %v", task.Tags)
+	}
+
+	inbox := archive.Projects[1]
+	if !ticktick.IsInboxID(inbox.Project.ID) || inbox.Tasks[0].Content != "Number in contacts" ||
+		inbox.Tasks[0].ID == "" {
+		t.Errorf("unexpected inbox: %+v", inbox)
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	archive := &backup.Archive{Projects: []backup.ProjectBackup{{
+		Project: ticktick.Project{ID: "p1", Name: "Home"},
+		Tasks: []ticktick.Task{
+			{ID: "t1", Title: "Parent", Content: "Notes", IsAllDay: true, Reminders: []string{"TRIGGER:PT9H"}},
+			{ID: "t2", Title: "Child", ParentID: "t1", RepeatFlag: "RRULE:FREQ=DAILY"},
+		},
+	}}}
+
+	var buf bytes.Buffer
+	if err := backup.WriteCSV(&buf, archive); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := backup.ReadCSV(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tasks := got.Projects[0].Tasks
+	if len(tasks) != 2 || tasks[0].Content != "Notes" || !tasks[0].IsAllDay || tasks[0].Reminders[0] != "TRIGGER:PT9H" {
+		t.Errorf("unexpected first task: %+v", tasks)
+	}
+
+	if tasks[1].ParentID != "t1" || tasks[1].RepeatFlag != "RRULE:FREQ=DAILY" {
+		t.Errorf("unexpected second task: %+v", tasks[1])
+	}
+}
+
+func TestReadCSVNoHeader(t *testing.T) {
+	if _, err := backup.ReadCSV(strings.NewReader("a,b\n")); err == nil {
+		t.Error("expected an error for a file without a header")
+	}
+}
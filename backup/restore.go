@@ -0,0 +1,392 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// ConflictPolicy decides what [Restore] does with an archived project whose
+// name matches a project of the account.
+type ConflictPolicy int
+
+// Conflict policies.
+const (
+	// Skip leaves the existing project untouched and does not restore the
+	// archived one.
+	Skip ConflictPolicy = iota
+	// Rename restores the archived project under a new name, such as
+	// "Work (restored)".
+	Rename
+	// Overwrite updates the existing project's settings and replaces its
+	// tasks with the archived ones. The existing tasks are deleted only after
+	// every archived task was restored, so a failed restore leaves them in
+	// place.
+	Overwrite
+)
+
+// RestoreOptions configures [Restore].
+type RestoreOptions struct {
+	Conflict ConflictPolicy
+	// Progress, if set, is called after each project and each task is restored.
+	Progress func(Progress)
+}
+
+// Progress reports how far a restore has come.
+type Progress struct {
+	// Done and Total count projects and tasks.
+	Done, Total int
+	// Project is the name of the archived project being restored.
+	Project string
+	// Task is the title of the task just restored, or "" after a project.
+	Task string
+}
+
+// RestoreResult reports the outcome of [Restore].
+type RestoreResult struct {
+	// Projects maps archived project IDs to the IDs of the restored projects.
+	Projects map[string]string
+	// Tasks maps archived task IDs to the IDs of the restored tasks, or of the
+	// existing Inbox tasks they were skipped for.
+	Tasks map[string]string
+	// Skipped lists the names of archived projects skipped by the Skip policy.
+	Skipped []string
+}
+
+// Restore reads an archive written by [Backup] and recreates its projects and
+// tasks; see [RestoreArchive].
+func Restore(ctx context.Context, client *ticktick.Client, r io.Reader, opts RestoreOptions) (*RestoreResult, error) {
+	archive, err := Read(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return RestoreArchive(ctx, client, archive, opts)
+}
+
+// RestoreArchive recreates the archive's projects and tasks through client.
+// Projects get new IDs, and references between tasks (ParentID) are remapped
+// to them. Inbox tasks are restored into the account's Inbox, where the
+// conflict policy applies to tasks with the title of an existing Inbox task:
+// Skip leaves the existing task alone, Overwrite replaces it and Rename
+// restores the archived task alongside it.
+//
+// The Open API cannot create kanban columns, so tasks keep their column only
+// when restored into an existing project (with the Overwrite policy) that has
+// a column of the same name. Closed projects are restored open.
+//
+// Restoring stops at the first error; the result then describes what was
+// restored so far.
+func RestoreArchive(
+	ctx context.Context, client *ticktick.Client, archive *Archive, opts RestoreOptions,
+) (*RestoreResult, error) {
+	existing, err := client.GetProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("backup: list projects: %w", err)
+	}
+
+	r := &restorer{
+		client: client,
+		opts:   opts,
+		names:  make(map[string]ticktick.Project, len(existing)),
+		result: &RestoreResult{Projects: map[string]string{}, Tasks: map[string]string{}},
+	}
+
+	for _, p := range existing {
+		r.names[p.Name] = p
+	}
+
+	for _, pb := range archive.Projects {
+		r.total += 1 + len(pb.Tasks)
+	}
+
+	for i := range archive.Projects {
+		if err := r.restoreProject(ctx, &archive.Projects[i]); err != nil {
+			return r.result, err
+		}
+	}
+
+	return r.result, nil
+}
+
+type restorer struct {
+	client      *ticktick.Client
+	opts        RestoreOptions
+	names       map[string]ticktick.Project
+	result      *RestoreResult
+	done, total int
+}
+
+// destination is the project an archived project is restored into.
+type destination struct {
+	projectID string
+	// columns maps archived to target column IDs.
+	columns map[string]string
+	// existing maps titles of Inbox tasks kept by the Skip policy to their IDs.
+	existing map[string]string
+	// replaced lists the IDs of existing tasks to delete once the archived
+	// tasks are restored.
+	replaced []string
+}
+
+func (r *restorer) restoreProject(ctx context.Context, pb *ProjectBackup) error {
+	dest, ok, err := r.target(ctx, pb)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		r.result.Skipped = append(r.result.Skipped, pb.Project.Name)
+		r.done += len(pb.Tasks)
+		r.progress(pb, "")
+
+		return nil
+	}
+
+	r.result.Projects[pb.Project.ID] = dest.projectID
+	r.progress(pb, "")
+
+	// Walking the task tree creates parents before their subtasks.
+	err = ticktick.WalkTasks(ticktick.BuildTaskTree(pb.Tasks), func(node *ticktick.TaskNode, _ int) error {
+		return r.restoreTask(ctx, pb, &node.Task, &dest)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range dest.replaced {
+		if err := r.client.DeleteTask(ctx, dest.projectID, id); err != nil {
+			return fmt.Errorf("backup: delete task %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// target returns the project to restore pb into. It reports false if the
+// project is skipped.
+func (r *restorer) target(ctx context.Context, pb *ProjectBackup) (destination, bool, error) {
+	if ticktick.IsInboxID(pb.Project.ID) {
+		dest, err := r.inbox(ctx, pb)
+
+		return dest, err == nil, err
+	}
+
+	current, exists := r.names[pb.Project.Name]
+	if !exists {
+		id, err := r.createProject(ctx, &pb.Project, pb.Project.Name)
+
+		return destination{projectID: id}, err == nil, err
+	}
+
+	switch r.opts.Conflict {
+	case Rename:
+		id, err := r.createProject(ctx, &pb.Project, r.uniqueName(pb.Project.Name))
+
+		return destination{projectID: id}, err == nil, err
+	case Overwrite:
+		dest, err := r.overwrite(ctx, current.ID, pb)
+
+		return dest, err == nil, err
+	case Skip:
+	}
+
+	return destination{}, false, nil
+}
+
+// inbox returns the account's Inbox as the destination of the archived Inbox,
+// applying the conflict policy to existing tasks with archived titles.
+func (r *restorer) inbox(ctx context.Context, pb *ProjectBackup) (destination, error) {
+	inbox, err := r.client.Inbox(ctx)
+	if err != nil {
+		return destination{}, fmt.Errorf("backup: read inbox: %w", err)
+	}
+
+	dest := destination{projectID: inbox.Project.ID, existing: map[string]string{}}
+
+	archived := make(map[string]bool, len(pb.Tasks))
+	for _, task := range pb.Tasks {
+		archived[task.Title] = true
+	}
+
+	for _, task := range inbox.Tasks {
+		if !archived[task.Title] {
+			continue
+		}
+
+		switch r.opts.Conflict {
+		case Skip:
+			if _, ok := dest.existing[task.Title]; !ok {
+				dest.existing[task.Title] = task.ID
+			}
+		case Overwrite:
+			dest.replaced = append(dest.replaced, task.ID)
+		case Rename:
+		}
+	}
+
+	return dest, nil
+}
+
+func (r *restorer) createProject(ctx context.Context, p *ticktick.Project, name string) (string, error) {
+	created, err := r.client.CreateProject(ctx, &ticktick.CreateProjectRequest{
+		Name:      name,
+		Color:     optional(p.Color),
+		SortOrder: ticktick.Int64(p.SortOrder),
+		ViewMode:  optional(p.ViewMode),
+		Kind:      optional(p.Kind),
+	})
+	if err != nil {
+		return "", fmt.Errorf("backup: create project %q: %w", name, err)
+	}
+
+	r.names[name] = *created
+
+	return created.ID, nil
+}
+
+// overwrite applies the archived settings to the existing project, maps
+// archived columns to its columns by name and lists its tasks for deletion.
+func (r *restorer) overwrite(ctx context.Context, projectID string, pb *ProjectBackup) (destination, error) {
+	_, err := r.client.UpdateProject(ctx, projectID, &ticktick.UpdateProjectRequest{
+		Color:     optional(pb.Project.Color),
+		SortOrder: ticktick.Int64(pb.Project.SortOrder),
+		ViewMode:  optional(pb.Project.ViewMode),
+		Kind:      optional(pb.Project.Kind),
+	})
+	if err != nil {
+		return destination{}, fmt.Errorf("backup: update project %q: %w", pb.Project.Name, err)
+	}
+
+	data, err := r.client.GetProjectData(ctx, projectID)
+	if err != nil {
+		return destination{}, fmt.Errorf("backup: read project %q: %w", pb.Project.Name, err)
+	}
+
+	dest := destination{projectID: projectID, columns: make(map[string]string)}
+
+	for _, task := range data.Tasks {
+		dest.replaced = append(dest.replaced, task.ID)
+	}
+
+	byName := make(map[string]string, len(data.Columns))
+	for _, col := range data.Columns {
+		byName[col.Name] = col.ID
+	}
+
+	for _, col := range pb.Columns {
+		if id, ok := byName[col.Name]; ok {
+			dest.columns[col.ID] = id
+		}
+	}
+
+	return dest, nil
+}
+
+func (r *restorer) restoreTask(ctx context.Context, pb *ProjectBackup, task *ticktick.Task, dest *destination) error {
+	if id, ok := dest.existing[task.Title]; ok {
+		r.result.Tasks[task.ID] = id
+		r.progress(pb, task.Title)
+
+		return nil
+	}
+
+	created, err := r.client.CreateTask(ctx, r.taskRequest(task, dest.projectID, dest.columns))
+	if err != nil {
+		return fmt.Errorf("backup: create task %q: %w", task.Title, err)
+	}
+
+	if task.Status == ticktick.TaskStatusCompleted {
+		if err := r.client.CompleteTask(ctx, dest.projectID, created.ID); err != nil {
+			return fmt.Errorf("backup: complete task %q: %w", task.Title, err)
+		}
+	}
+
+	r.result.Tasks[task.ID] = created.ID
+	r.progress(pb, task.Title)
+
+	return nil
+}
+
+func (r *restorer) taskRequest(
+	task *ticktick.Task, projectID string, columns map[string]string,
+) *ticktick.CreateTaskRequest {
+	req := &ticktick.CreateTaskRequest{
+		Title:      task.Title,
+		ProjectID:  projectID,
+		Content:    optional(task.Content),
+		Desc:       optional(task.Desc),
+		IsAllDay:   ticktick.Bool(task.IsAllDay),
+		StartDate:  optionalTime(task.StartDate),
+		DueDate:    optionalTime(task.DueDate),
+		TimeZone:   optional(task.TimeZone),
+		Reminders:  slices.Clone(task.Reminders),
+		RepeatFlag: optional(task.RepeatFlag),
+		Priority:   ticktick.Int(task.Priority),
+		SortOrder:  ticktick.Int64(task.SortOrder),
+		Tags:       slices.Clone(task.Tags),
+		Kind:       optional(task.Kind),
+	}
+
+	if id, ok := columns[task.ColumnID]; ok {
+		req.ColumnID = ticktick.String(id)
+	}
+
+	if id, ok := r.result.Tasks[task.ParentID]; ok {
+		req.ParentID = ticktick.String(id)
+	}
+
+	for _, item := range task.Items {
+		req.Items = append(req.Items, ticktick.CreateChecklistItemRequest{
+			Title:         item.Title,
+			StartDate:     optionalTime(item.StartDate),
+			IsAllDay:      ticktick.Bool(item.IsAllDay),
+			SortOrder:     ticktick.Int64(item.SortOrder),
+			TimeZone:      optional(item.TimeZone),
+			Status:        ticktick.Int(item.Status),
+			CompletedTime: optionalTime(item.CompletedTime),
+		})
+	}
+
+	return req
+}
+
+func (r *restorer) uniqueName(name string) string {
+	candidate := name + " (restored)"
+
+	for n := 2; ; n++ {
+		if _, taken := r.names[candidate]; !taken {
+			return candidate
+		}
+
+		candidate = name + " (restored " + strconv.Itoa(n) + ")"
+	}
+}
+
+func (r *restorer) progress(pb *ProjectBackup, task string) {
+	r.done++
+
+	if r.opts.Progress != nil {
+		r.opts.Progress(Progress{Done: r.done, Total: r.total, Project: pb.Project.Name, Task: task})
+	}
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return ticktick.String(s)
+}
+
+func optionalTime(t ticktick.Time) *ticktick.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return ticktick.NewTime(t.Time)
+}
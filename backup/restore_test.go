@@ -0,0 +1,300 @@
+package backup_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/backup"
+)
+
+// fakeAccount is an in-memory stand-in for the Open API that records writes.
+type fakeAccount struct {
+	t        *testing.T
+	projects []ticktick.Project
+	data     map[string]ticktick.ProjectData
+	created  []ticktick.CreateTaskRequest
+	log      []string
+	// failTitle makes creating a task with this title fail.
+	failTitle string
+}
+
+func (f *fakeAccount) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.Method + " " + r.URL.Path
+
+	switch {
+	case path == "GET /open/v1/project":
+		json.NewEncoder(w).Encode(f.projects)
+	case path == "POST /open/v1/project":
+		var req ticktick.CreateProjectRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		f.log = append(f.log, "create project "+req.Name)
+		json.NewEncoder(w).Encode(ticktick.Project{ID: "new-" + req.Name, Name: req.Name})
+	case path == "POST /open/v1/task":
+		var req ticktick.CreateTaskRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if f.failTitle != "" && req.Title == f.failTitle {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		f.created = append(f.created, req)
+		json.NewEncoder(w).Encode(ticktick.Task{ID: "new-" + req.Title, ProjectID: req.ProjectID})
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/data"):
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/open/v1/project/"), "/data")
+		json.NewEncoder(w).Encode(f.data[id])
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/open/v1/project/"):
+		f.log = append(f.log, strings.ToLower(r.Method)+" "+r.URL.Path)
+		json.NewEncoder(w).Encode(ticktick.Project{})
+	case r.Method == http.MethodDelete:
+		f.log = append(f.log, "delete "+r.URL.Path)
+	default:
+		f.t.Errorf("unexpected request: %s", path)
+	}
+}
+
+func newFakeAccount(t *testing.T, f *fakeAccount) *ticktick.Client {
+	t.Helper()
+
+	f.t = t
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	return ticktick.NewClient("token", ticktick.WithBaseURL(server.URL))
+}
+
+func testArchive() *backup.Archive {
+	return &backup.Archive{
+		Version: backup.Version,
+		Projects: []backup.ProjectBackup{
+			{
+				Project: ticktick.Project{ID: "old-p1", Name: "Work", Color: "#F18181"},
+				Columns: []ticktick.Column{{ID: "old-c1", Name: "Doing"}},
+				Tasks: []ticktick.Task{
+					{ID: "old-child", Title: "Child", ParentID: "old-parent", ColumnID: "old-c1"},
+					{ID: "old-parent", Title: "Parent", Status: ticktick.TaskStatusCompleted,
+						Items: []ticktick.ChecklistItem{{Title: "Step", Status: ticktick.ChecklistStatusCompleted}}},
+				},
+			},
+			{
+				Project: ticktick.Project{ID: "inbox1", Name: "Inbox"},
+				Tasks:   []ticktick.Task{{ID: "old-capture", Title: "Capture"}},
+			},
+		},
+	}
+}
+
+func TestRestoreArchive(t *testing.T) {
+	account := &fakeAccount{data: map[string]ticktick.ProjectData{
+		"inbox": {Tasks: []ticktick.Task{{ID: "x", ProjectID: "inbox99"}}},
+	}}
+	client := newFakeAccount(t, account)
+
+	var progress []backup.Progress
+
+	result, err := backup.RestoreArchive(context.Background(), client, testArchive(), backup.RestoreOptions{
+		Progress: func(p backup.Progress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Projects["old-p1"] != "new-Work" || result.Projects["inbox1"] != "inbox99" {
+		t.Errorf("unexpected project mapping: %v", result.Projects)
+	}
+
+	if len(account.created) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(account.created))
+	}
+
+	parent, child, capture := account.created[0], account.created[1], account.created[2]
+
+	if parent.Title != "Parent" || len(parent.Items) != 1 ||
+		*parent.Items[0].Status != ticktick.ChecklistStatusCompleted {
+		t.Errorf("expected the parent first with its items, got %+v", parent)
+	}
+
+	if child.ParentID == nil || *child.ParentID != "new-Parent" {
+		t.Errorf("expected remapped parent ID, got %v", child.ParentID)
+	}
+
+	if child.ColumnID != nil {
+		t.Errorf("expected no column in a new project, got %s", *child.ColumnID)
+	}
+
+	if capture.ProjectID != "inbox99" {
+		t.Errorf("expected the inbox task in inbox99, got %s", capture.ProjectID)
+	}
+
+	wantLog := []string{"create project Work", "post /open/v1/project/new-Work/task/new-Parent/complete"}
+	if strings.Join(account.log, "|") != strings.Join(wantLog, "|") {
+		t.Errorf("expected %v, got %v", wantLog, account.log)
+	}
+
+	if len(progress) != 5 || progress[4].Done != 5 || progress[4].Total != 5 || progress[4].Task != "Capture" {
+		t.Errorf("unexpected progress: %+v", progress)
+	}
+}
+
+func TestRestoreConflicts(t *testing.T) {
+	existing := []ticktick.Project{{ID: "cur-p1", Name: "Work"}, {ID: "cur-p2", Name: "Work (restored)"}}
+
+	tests := []struct {
+		name     string
+		policy   backup.ConflictPolicy
+		wantLog  []string
+		wantTask int
+	}{
+		{"skip", backup.Skip, nil, 1},
+		{"rename", backup.Rename, []string{"create project Work (restored 2)",
+			"post /open/v1/project/new-Work (restored 2)/task/new-Parent/complete"}, 3},
+		{"overwrite", backup.Overwrite, []string{"post /open/v1/project/cur-p1",
+			"post /open/v1/project/cur-p1/task/new-Parent/complete", "delete /open/v1/project/cur-p1/task/stale"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := &fakeAccount{
+				projects: existing,
+				data: map[string]ticktick.ProjectData{
+					"cur-p1": {
+						Tasks:   []ticktick.Task{{ID: "stale"}},
+						Columns: []ticktick.Column{{ID: "cur-c1", Name: "Doing"}},
+					},
+					"inbox": {},
+				},
+			}
+			client := newFakeAccount(t, account)
+
+			result, err := backup.RestoreArchive(context.Background(), client, testArchive(),
+				backup.RestoreOptions{Conflict: tt.policy})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if strings.Join(account.log, "|") != strings.Join(tt.wantLog, "|") {
+				t.Errorf("expected %v, got %v", tt.wantLog, account.log)
+			}
+
+			if len(account.created) != tt.wantTask {
+				t.Errorf("expected %d tasks, got %d", tt.wantTask, len(account.created))
+			}
+
+			if tt.policy == backup.Skip && (len(result.Skipped) != 1 || result.Skipped[0] != "Work") {
+				t.Errorf("expected Work to be skipped, got %v", result.Skipped)
+			}
+
+			if tt.policy == backup.Overwrite {
+				child := account.created[1]
+				if child.ColumnID == nil || *child.ColumnID != "cur-c1" {
+					t.Errorf("expected the column to be mapped by name, got %v", child.ColumnID)
+				}
+			}
+		})
+	}
+}
+
+func TestRestoreOverwriteFailure(t *testing.T) {
+	account := &fakeAccount{
+		projects:  []ticktick.Project{{ID: "cur-p1", Name: "Work"}},
+		data:      map[string]ticktick.ProjectData{"cur-p1": {Tasks: []ticktick.Task{{ID: "stale"}}}},
+		failTitle: "Child",
+	}
+	client := newFakeAccount(t, account)
+
+	// The failing subtask has a sibling after it, which must not hide the error.
+	archive := testArchive()
+	archive.Projects[0].Tasks = append(archive.Projects[0].Tasks,
+		ticktick.Task{ID: "old-sibling", Title: "Sibling", ParentID: "old-parent", SortOrder: 1})
+
+	_, err := backup.RestoreArchive(context.Background(), client, archive,
+		backup.RestoreOptions{Conflict: backup.Overwrite})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, entry := range account.log {
+		if strings.HasPrefix(entry, "delete ") {
+			t.Errorf("expected existing tasks to be kept after a failure, got %v", account.log)
+		}
+	}
+}
+
+func TestRestoreInboxConflicts(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     backup.ConflictPolicy
+		wantCreate bool
+		wantDelete bool
+	}{
+		{"skip", backup.Skip, false, false},
+		{"rename", backup.Rename, true, false},
+		{"overwrite", backup.Overwrite, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := &fakeAccount{data: map[string]ticktick.ProjectData{
+				"inbox": {Tasks: []ticktick.Task{
+					{ID: "cur-capture", ProjectID: "inbox99", Title: "Capture"},
+					{ID: "other", ProjectID: "inbox99", Title: "Other"},
+				}},
+			}}
+			client := newFakeAccount(t, account)
+
+			archive := testArchive()
+			archive.Projects = archive.Projects[1:]
+
+			result, err := backup.RestoreArchive(context.Background(), client, archive,
+				backup.RestoreOptions{Conflict: tt.policy})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if created := len(account.created) == 1; created != tt.wantCreate {
+				t.Errorf("expected the task to be created: %v, got %+v", tt.wantCreate, account.created)
+			}
+
+			var want []string
+			if tt.wantDelete {
+				want = []string{"delete /open/v1/project/inbox99/task/cur-capture"}
+			}
+
+			if strings.Join(account.log, "|") != strings.Join(want, "|") {
+				t.Errorf("expected %v, got %v", want, account.log)
+			}
+
+			if !tt.wantCreate && result.Tasks["old-capture"] != "cur-capture" {
+				t.Errorf("expected the existing task to be mapped, got %v", result.Tasks)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	client := newFakeAccount(t, &fakeAccount{data: map[string]ticktick.ProjectData{"inbox": {}}})
+
+	_, err := backup.Restore(context.Background(), client, strings.NewReader(`{"version": 2}`),
+		backup.RestoreOptions{})
+	if err == nil {
+		t.Fatal("expected an error for an unsupported version")
+	}
+
+	const archive = `{"version": 1, "projects": [{"project": {"id": "p", "name": "P"}}]}`
+
+	result, err := backup.Restore(context.Background(), client, strings.NewReader(archive), backup.RestoreOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Projects["p"] != "new-P" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
@@ -0,0 +1,499 @@
+// Package cache keeps TickTick projects and tasks locally, so that tools run
+// repeatedly do not download every project's data each time.
+//
+// A [Cache] serves [Cache.GetProjects] and [Cache.GetProjectData] from a
+// [Store] while the stored responses are younger than its TTL, and fetches
+// them through the client otherwise. [MemoryStore] keeps the responses for the
+// life of the process; [FileStore] keeps them in a directory, so that the next
+// invocation of a tool starts from them:
+//
+//	client := ticktick.NewClient(token)
+//	c := cache.New(client, cache.NewFileStore(filepath.Join(dir, "ticktick")), cache.WithTTL(time.Hour))
+//
+//	tasks, err := c.AllTasks(ctx)
+//
+// Writes made through the client invalidate the affected entries: the Cache
+// registers itself with [ticktick.Client.OnChange], so a task created,
+// updated, completed or deleted through the client is visible in the next
+// read. Long-running programs can call [Cache.Run] in a goroutine to refresh
+// the entries in the background, one project at a time.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// DefaultTTL is how long a [Cache] serves a stored response before fetching
+// it again.
+const DefaultTTL = 5 * time.Minute
+
+const (
+	projectsKey   = "projects"
+	dataKeyPrefix = "project/"
+)
+
+// Cache serves projects and tasks from a [Store], fetching them through a
+// [ticktick.Client] once they are older than the TTL.
+//
+// If a fetch fails because the API cannot be reached, the stored response is
+// served even when it is stale or invalidated, so tools keep working offline.
+// Errors returned by the API itself are passed on.
+type Cache struct {
+	client *ticktick.Client
+	store  Store
+	ttl    time.Duration
+	now    func() time.Time
+
+	// mu guards generations and stale. generations counts the invalidations
+	// of each key, so that a response fetched before a write is not stored
+	// after it; stale holds the keys invalidated since they were last
+	// stored, in case the Store failed to record the invalidation. mu also
+	// guards the task index: holders maps task IDs to the keys of the stored
+	// entries holding them, and held maps keys to the IDs of their tasks.
+	// indexed reports whether the entries stored before the Cache was created
+	// have been added to the index. The Store is never accessed while mu is
+	// held.
+	mu          sync.Mutex
+	generations map[string]uint64
+	stale       map[string]bool
+	holders     map[string]map[string]bool
+	held        map[string][]string
+	indexed     bool
+}
+
+// Option configures a [Cache].
+type Option func(*Cache)
+
+// WithTTL sets how long stored responses are served. The default is
+// [DefaultTTL]; a TTL of zero or less fetches on every read, keeping the
+// stored responses for offline use only.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Cache) {
+		c.ttl = ttl
+	}
+}
+
+// New creates a Cache that fetches through client and keeps the responses in
+// store. It registers the Cache with client's [ticktick.Client.OnChange].
+func New(client *ticktick.Client, store Store, opts ...Option) *Cache {
+	c := &Cache{
+		client:      client,
+		store:       store,
+		ttl:         DefaultTTL,
+		now:         time.Now,
+		generations: make(map[string]uint64),
+		stale:       make(map[string]bool),
+		holders:     make(map[string]map[string]bool),
+		held:        make(map[string][]string),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	client.OnChange(c.handleChange)
+
+	return c
+}
+
+// GetProjects returns all projects, like [ticktick.Client.GetProjects].
+func (c *Cache) GetProjects(ctx context.Context) ([]ticktick.Project, error) {
+	entry, err := c.load(ctx, projectsKey, func(ctx context.Context) (*Entry, error) {
+		projects, err := c.client.GetProjects(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Entry{Projects: projects}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.Projects, nil
+}
+
+// GetProjectData returns a project along with its tasks and columns, like
+// [ticktick.Client.GetProjectData].
+func (c *Cache) GetProjectData(ctx context.Context, projectID string) (*ticktick.ProjectData, error) {
+	entry, err := c.load(ctx, dataKey(projectID), func(ctx context.Context) (*Entry, error) {
+		data, err := c.client.GetProjectData(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Entry{Data: data}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.Data, nil
+}
+
+// AllTasks returns the tasks of every open project, like
+// [ticktick.Client.AllTasks].
+func (c *Cache) AllTasks(ctx context.Context) ([]ticktick.Task, error) {
+	projects, err := c.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []ticktick.Task
+
+	for _, p := range projects {
+		if p.Closed {
+			continue
+		}
+
+		data, err := c.GetProjectData(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, data.Tasks...)
+	}
+
+	return tasks, nil
+}
+
+// Invalidate makes the next read of the project's data fetch it again. Writes
+// made through the Cache's client invalidate the affected projects
+// automatically; Invalidate is for writes made by other means.
+func (c *Cache) Invalidate(ctx context.Context, projectID string) error {
+	return c.invalidate(ctx, dataKey(projectID))
+}
+
+// Refresh fetches the project list if it is older than the TTL, and then, one
+// project at a time, the data of each open project and of each other stored
+// project that is older than the TTL. Stored data of projects that are no
+// longer in the list is deleted, except for the Inbox, which never is. Errors
+// do not stop the refresh; they are returned together at the end.
+func (c *Cache) Refresh(ctx context.Context) error {
+	projects, err := c.GetProjects(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys, err := c.store.Keys(ctx)
+	if err != nil {
+		return fmt.Errorf("cache: list entries: %w", err)
+	}
+
+	var (
+		ids    []string
+		listed = make(map[string]bool, len(projects))
+		errs   []error
+	)
+
+	for _, p := range projects {
+		listed[p.ID] = true
+
+		if !p.Closed {
+			ids = append(ids, p.ID)
+		}
+	}
+
+	for _, key := range keys {
+		id, ok := strings.CutPrefix(key, dataKeyPrefix)
+		if !ok || listed[id] {
+			continue
+		}
+
+		if ticktick.IsInboxID(id) {
+			ids = append(ids, id)
+
+			continue
+		}
+
+		if err := c.store.Delete(ctx, key); err != nil {
+			errs = append(errs, fmt.Errorf("cache: delete %s: %w", key, err))
+
+			continue
+		}
+
+		c.index(key, nil)
+	}
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return errors.Join(append(errs, ctx.Err())...)
+		}
+
+		if _, err := c.GetProjectData(ctx, id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run calls [Cache.Refresh] every interval until ctx is done. Errors are not
+// reported; the entries that failed to refresh are retried at the next
+// interval, and reads fetch them in the meantime. Call Refresh directly to
+// handle errors.
+func (c *Cache) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_ = c.Refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// load returns the entry stored under key while it is fresh, and the entry
+// fetch returns otherwise.
+func (c *Cache) load(ctx context.Context, key string, fetch func(context.Context) (*Entry, error)) (*Entry, error) {
+	stored, err := c.store.Load(ctx, key)
+	if err != nil && !errors.Is(err, ErrNotCached) {
+		return nil, fmt.Errorf("cache: load %s: %w", key, err)
+	}
+
+	if stored != nil {
+		c.index(key, stored)
+
+		if c.fresh(key, stored) {
+			return stored, nil
+		}
+	}
+
+	generation := c.generation(key)
+
+	entry, err := fetch(ctx)
+	if err != nil {
+		if stored != nil && offline(ctx, err) {
+			return stored, nil
+		}
+
+		return nil, err
+	}
+
+	entry.FetchedAt = c.now()
+
+	if err := c.save(ctx, key, entry, generation); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (c *Cache) fresh(key string, entry *Entry) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return !c.stale[key] && !entry.FetchedAt.IsZero() && c.now().Sub(entry.FetchedAt) < c.ttl
+}
+
+func (c *Cache) generation(key string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generations[key]
+}
+
+// save stores a fetched entry unless the key was invalidated after the fetch
+// started, in which case the entry may predate the write that invalidated it.
+// The Store is written without holding mu; if the key is invalidated in the
+// meantime, the saved entry is expired again.
+func (c *Cache) save(ctx context.Context, key string, entry *Entry, generation uint64) error {
+	if c.generation(key) != generation {
+		return nil
+	}
+
+	if err := c.store.Save(ctx, key, entry); err != nil {
+		return fmt.Errorf("cache: save %s: %w", key, err)
+	}
+
+	c.index(key, entry)
+
+	if !c.saved(key, generation) {
+		return c.expire(ctx, key)
+	}
+
+	return nil
+}
+
+// saved records that an entry of the given generation was stored under key.
+// It reports false if the key was invalidated since.
+func (c *Cache) saved(key string, generation uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations[key] != generation {
+		return false
+	}
+
+	delete(c.stale, key)
+
+	return true
+}
+
+func (c *Cache) invalidate(ctx context.Context, key string) error {
+	c.mu.Lock()
+	c.generations[key]++
+	c.stale[key] = true
+	c.mu.Unlock()
+
+	return c.expire(ctx, key)
+}
+
+// expire clears the fetch time of the entry stored under key, so that other
+// Caches sharing the Store fetch it again too.
+func (c *Cache) expire(ctx context.Context, key string) error {
+	entry, err := c.store.Load(ctx, key)
+	if errors.Is(err, ErrNotCached) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("cache: load %s: %w", key, err)
+	}
+
+	entry.FetchedAt = time.Time{}
+
+	if err := c.store.Save(ctx, key, entry); err != nil {
+		return fmt.Errorf("cache: save %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// handleChange invalidates the entries affected by a write made through the
+// client. A task write invalidates its project and, for a task moved between
+// projects, the project it left. When that project is unknown, every stored
+// project holding the task is invalidated, except after the task was created.
+// A project write also invalidates the project list. Store errors are ignored, as the invalidation is
+// remembered in memory regardless.
+func (c *Cache) handleChange(change ticktick.Change) {
+	ctx := context.Background()
+	keys := []string{dataKey(change.ProjectID)}
+
+	switch {
+	case change.TaskID == "":
+		keys = append(keys, projectsKey)
+	case change.PreviousProjectID != "":
+		keys = append(keys, dataKey(change.PreviousProjectID))
+	case change.Created:
+		// A new task is not in any stored project yet.
+	default:
+		keys = append(keys, c.keysHoldingTask(ctx, change.TaskID)...)
+	}
+
+	for _, id := range []string{change.ProjectID, change.PreviousProjectID} {
+		if ticktick.IsInboxID(id) {
+			keys = append(keys, dataKey(ticktick.InboxProjectID))
+		}
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range slices.Compact(keys) {
+		_ = c.invalidate(ctx, key)
+	}
+}
+
+// keysHoldingTask returns the keys of the stored projects that hold the task.
+// The first call indexes the entries already in the Store; later calls only
+// consult the index, which is kept up to date as entries are loaded and saved.
+func (c *Cache) keysHoldingTask(ctx context.Context, taskID string) []string {
+	c.mu.Lock()
+	indexed := c.indexed
+	c.mu.Unlock()
+
+	if !indexed && c.indexStore(ctx) {
+		c.mu.Lock()
+		c.indexed = true
+		c.mu.Unlock()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Sorted(maps.Keys(c.holders[taskID]))
+}
+
+// indexStore adds every project entry in the Store to the task index. It
+// reports whether the Store could be listed.
+func (c *Cache) indexStore(ctx context.Context) bool {
+	keys, err := c.store.Keys(ctx)
+	if err != nil {
+		return false
+	}
+
+	for _, key := range keys {
+		if !strings.HasPrefix(key, dataKeyPrefix) {
+			continue
+		}
+
+		if entry, err := c.store.Load(ctx, key); err == nil {
+			c.index(key, entry)
+		}
+	}
+
+	return true
+}
+
+// index records the tasks of the entry stored under key in the task index,
+// replacing those of the entry it held before. A nil entry removes the key.
+func (c *Cache) index(key string, entry *Entry) {
+	if !strings.HasPrefix(key, dataKeyPrefix) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range c.held[key] {
+		delete(c.holders[id], key)
+
+		if len(c.holders[id]) == 0 {
+			delete(c.holders, id)
+		}
+	}
+
+	delete(c.held, key)
+
+	if entry == nil || entry.Data == nil {
+		return
+	}
+
+	ids := make([]string, 0, len(entry.Data.Tasks))
+
+	for _, task := range entry.Data.Tasks {
+		if c.holders[task.ID] == nil {
+			c.holders[task.ID] = make(map[string]bool)
+		}
+
+		c.holders[task.ID][key] = true
+		ids = append(ids, task.ID)
+	}
+
+	c.held[key] = ids
+}
+
+func dataKey(projectID string) string {
+	return dataKeyPrefix + projectID
+}
+
+// offline reports whether err means the API could not be reached, as opposed
+// to an error response or a canceled request.
+func offline(ctx context.Context, err error) bool {
+	var apiErr *ticktick.Error
+
+	return ctx.Err() == nil && !errors.As(err, &apiErr)
+}
@@ -0,0 +1,453 @@
+package cache_test
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/cache"
+)
+
+// fakeAPI serves two projects and counts the requests for each path.
+type fakeAPI struct {
+	mu       sync.Mutex
+	requests map[string]int
+	projects []ticktick.Project
+	tasks    map[string][]ticktick.Task
+	// moveTo, if set, is the project an update of t1 moves it to, whatever
+	// project the request names.
+	moveTo string
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{
+		requests: make(map[string]int),
+		projects: []ticktick.Project{{ID: "p1", Name: "Work"}, {ID: "p2", Name: "Home"}},
+		tasks: map[string][]ticktick.Task{
+			"p1": {{ID: "t1", ProjectID: "p1", Title: "Write"}},
+			"p2": {{ID: "t2", ProjectID: "p2", Title: "Clean"}},
+		},
+	}
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests[r.Method+" "+r.URL.Path]++
+
+	switch {
+	case r.URL.Path == "/open/v1/project":
+		json.NewEncoder(w).Encode(f.projects)
+	case strings.HasSuffix(r.URL.Path, "/data"):
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/open/v1/project/"), "/data")
+		for _, p := range f.projects {
+			if p.ID == id {
+				json.NewEncoder(w).Encode(ticktick.ProjectData{Project: p, Tasks: f.tasks[id]})
+
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	case r.URL.Path == "/open/v1/task":
+		var req ticktick.CreateTaskRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		task := ticktick.Task{ID: "new", ProjectID: req.ProjectID, Title: req.Title}
+		f.tasks[req.ProjectID] = append(f.tasks[req.ProjectID], task)
+		json.NewEncoder(w).Encode(task)
+	case r.URL.Path == "/open/v1/task/t1":
+		var req ticktick.UpdateTaskRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		task := ticktick.Task{ID: "t1", ProjectID: cmp.Or(f.moveTo, req.ProjectID), Title: "Write"}
+		f.tasks["p1"] = nil
+		f.tasks[task.ProjectID] = append(f.tasks[task.ProjectID], task)
+		json.NewEncoder(w).Encode(task)
+	case r.Method == http.MethodDelete:
+		f.tasks["p1"] = nil
+	}
+}
+
+func (f *fakeAPI) count(method, path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requests[method+" "+path]
+}
+
+func setup(t *testing.T, store cache.Store, opts ...cache.Option) (*fakeAPI, *ticktick.Client, *cache.Cache) {
+	t.Helper()
+
+	api := newFakeAPI()
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	client := ticktick.NewClient("token", ticktick.WithBaseURL(server.URL))
+
+	return api, client, cache.New(client, store, opts...)
+}
+
+func TestCacheServesWithinTTL(t *testing.T) {
+	ctx := context.Background()
+	api, _, c := setup(t, &cache.MemoryStore{})
+
+	for range 2 {
+		tasks, err := c.AllTasks(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(tasks) != 2 {
+			t.Fatalf("expected 2 tasks, got %+v", tasks)
+		}
+	}
+
+	if n := api.count(http.MethodGet, "/open/v1/project"); n != 1 {
+		t.Errorf("expected 1 project list request, got %d", n)
+	}
+
+	if n := api.count(http.MethodGet, "/open/v1/project/p1/data"); n != 1 {
+		t.Errorf("expected 1 project data request, got %d", n)
+	}
+}
+
+func TestCacheExpires(t *testing.T) {
+	ctx := context.Background()
+	api, _, c := setup(t, &cache.MemoryStore{}, cache.WithTTL(0))
+
+	for range 2 {
+		if _, err := c.GetProjectData(ctx, "p1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if n := api.count(http.MethodGet, "/open/v1/project/p1/data"); n != 2 {
+		t.Errorf("expected 2 project data requests, got %d", n)
+	}
+}
+
+func TestCacheInvalidatesOnWrites(t *testing.T) {
+	ctx := context.Background()
+	api, client, c := setup(t, &cache.MemoryStore{})
+
+	if _, err := c.AllTasks(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.CreateTask(ctx, &ticktick.CreateTaskRequest{Title: "New", ProjectID: "p2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := c.GetProjectData(ctx, "p2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(data.Tasks) != 2 {
+		t.Errorf("expected the created task to be visible, got %+v", data.Tasks)
+	}
+
+	// Moving t1 from p1 to p2 invalidates both projects.
+	if _, err := client.UpdateTask(ctx, "t1", &ticktick.UpdateTaskRequest{ID: "t1", ProjectID: "p2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if data, _ := c.GetProjectData(ctx, "p1"); len(data.Tasks) != 0 {
+		t.Errorf("expected the moved task to leave p1, got %+v", data.Tasks)
+	}
+
+	if data, _ := c.GetProjectData(ctx, "p2"); len(data.Tasks) != 3 {
+		t.Errorf("expected the moved task in p2, got %+v", data.Tasks)
+	}
+
+	if n := api.count(http.MethodGet, "/open/v1/project"); n != 1 {
+		t.Errorf("expected task writes to keep the project list, got %d requests", n)
+	}
+}
+
+// hookStore is a MemoryStore that counts Keys calls and runs beforeSave, once,
+// before the next Save.
+type hookStore struct {
+	cache.MemoryStore
+
+	keys       int
+	beforeSave func(key string)
+}
+
+func (s *hookStore) Keys(ctx context.Context) ([]string, error) {
+	s.keys++
+
+	return s.MemoryStore.Keys(ctx)
+}
+
+func (s *hookStore) Save(ctx context.Context, key string, entry *cache.Entry) error {
+	if hook := s.beforeSave; hook != nil {
+		s.beforeSave = nil
+		hook(key)
+	}
+
+	return s.MemoryStore.Save(ctx, key, entry)
+}
+
+func TestCacheInvalidatesPreviousProject(t *testing.T) {
+	ctx := context.Background()
+	store := &hookStore{}
+	api, client, c := setup(t, store)
+
+	if _, err := c.AllTasks(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	api.mu.Lock()
+	api.moveTo = "p2"
+	api.mu.Unlock()
+
+	// The request names the project t1 leaves, so no stored project is scanned.
+	if _, err := client.UpdateTask(ctx, "t1", &ticktick.UpdateTaskRequest{ID: "t1", ProjectID: "p1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if store.keys != 0 {
+		t.Errorf("expected no scan of stored projects, got %d", store.keys)
+	}
+
+	if data, _ := c.GetProjectData(ctx, "p1"); len(data.Tasks) != 0 {
+		t.Errorf("expected the moved task to leave p1, got %+v", data.Tasks)
+	}
+
+	if data, _ := c.GetProjectData(ctx, "p2"); len(data.Tasks) != 2 {
+		t.Errorf("expected the moved task in p2, got %+v", data.Tasks)
+	}
+}
+
+func TestCacheTaskIndex(t *testing.T) {
+	ctx := context.Background()
+	store := &hookStore{}
+	_, client, c := setup(t, store)
+
+	if _, err := c.AllTasks(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.CreateTask(ctx, &ticktick.CreateTaskRequest{Title: "New", ProjectID: "p2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if store.keys != 0 {
+		t.Errorf("expected no scan of stored projects for a new task, got %d", store.keys)
+	}
+
+	// Moves that name only the new project find the old one in the index,
+	// which is built from the Store once.
+	for range 2 {
+		if _, err := client.UpdateTask(ctx, "t1", &ticktick.UpdateTaskRequest{ID: "t1", ProjectID: "p2"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if store.keys != 1 {
+		t.Errorf("expected one scan of stored projects, got %d", store.keys)
+	}
+
+	if data, _ := c.GetProjectData(ctx, "p1"); len(data.Tasks) != 0 {
+		t.Errorf("expected the moved task to leave p1, got %+v", data.Tasks)
+	}
+}
+
+func TestCacheInvalidatedWhileSaving(t *testing.T) {
+	ctx := context.Background()
+	store := &hookStore{}
+	api, _, c := setup(t, store)
+
+	// An invalidation racing with the save of an older fetch; the Store is
+	// written without holding the Cache's lock, so this does not deadlock.
+	store.beforeSave = func(string) {
+		if err := c.Invalidate(ctx, "p1"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := c.GetProjectData(ctx, "p1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if entry, _ := store.Load(ctx, "project/p1"); entry == nil || !entry.FetchedAt.IsZero() {
+		t.Errorf("expected the saved entry to be expired, got %+v", entry)
+	}
+
+	if _, err := c.GetProjectData(ctx, "p1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := api.count(http.MethodGet, "/open/v1/project/p1/data"); n != 2 {
+		t.Errorf("expected the invalidated data to be fetched again, got %d requests", n)
+	}
+}
+
+func TestCacheFileStoreOutlivesCache(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	api, client, c := setup(t, cache.NewFileStore(dir))
+
+	if _, err := c.AllTasks(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A second invocation of a tool starts from the stored responses.
+	next := cache.New(client, cache.NewFileStore(dir))
+
+	if _, err := next.AllTasks(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := api.count(http.MethodGet, "/open/v1/project/p2/data"); n != 1 {
+		t.Errorf("expected 1 project data request, got %d", n)
+	}
+
+	// The invalidation is stored, so other processes see it too.
+	if err := client.DeleteTask(ctx, "p1", "t1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	other := cache.New(ticktick.NewClient("token", ticktick.WithBaseURL(serverURL(t, api))), cache.NewFileStore(dir))
+
+	if data, _ := other.GetProjectData(ctx, "p1"); len(data.Tasks) != 0 {
+		t.Errorf("expected the deleted task to be gone, got %+v", data.Tasks)
+	}
+}
+
+func TestCacheOffline(t *testing.T) {
+	ctx := context.Background()
+	store := &cache.MemoryStore{}
+	api := newFakeAPI()
+	server := httptest.NewServer(api)
+
+	c := cache.New(ticktick.NewClient("token", ticktick.WithBaseURL(server.URL)), store, cache.WithTTL(0))
+
+	if _, err := c.GetProjectData(ctx, "p1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	server.Close()
+
+	data, err := c.GetProjectData(ctx, "p1")
+	if err != nil {
+		t.Fatalf("expected the stored data offline, got %v", err)
+	}
+
+	if data.Tasks[0].ID != "t1" {
+		t.Errorf("unexpected data: %+v", data)
+	}
+
+	if _, err := c.GetProjectData(ctx, "p2"); err == nil {
+		t.Error("expected an error for data never fetched")
+	}
+}
+
+func TestCacheAPIErrorNotMasked(t *testing.T) {
+	ctx := context.Background()
+	api, _, c := setup(t, &cache.MemoryStore{}, cache.WithTTL(0))
+
+	if _, err := c.GetProjectData(ctx, "p2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	api.mu.Lock()
+	api.projects = api.projects[:1]
+	api.mu.Unlock()
+
+	var apiErr *ticktick.Error
+	if _, err := c.GetProjectData(ctx, "p2"); !errors.As(err, &apiErr) {
+		t.Errorf("expected an API error, got %v", err)
+	}
+}
+
+func TestCacheRefresh(t *testing.T) {
+	ctx := context.Background()
+	store := &cache.MemoryStore{}
+	api, _, c := setup(t, store, cache.WithTTL(time.Hour))
+
+	store.Save(ctx, "project/gone", &cache.Entry{FetchedAt: time.Now(), Data: &ticktick.ProjectData{}})
+
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys, _ := store.Keys(ctx)
+	if strings.Join(keys, ",") != "project/p1,project/p2,projects" {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := api.count(http.MethodGet, "/open/v1/project/p1/data"); n != 1 {
+		t.Errorf("expected fresh entries to be kept, got %d requests", n)
+	}
+
+	if err := c.Invalidate(ctx, "p1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := api.count(http.MethodGet, "/open/v1/project/p1/data"); n != 2 {
+		t.Errorf("expected the invalidated project to be refreshed, got %d requests", n)
+	}
+
+	if n := api.count(http.MethodGet, "/open/v1/project/p2/data"); n != 1 {
+		t.Errorf("expected other projects to be kept, got %d requests", n)
+	}
+}
+
+func TestCacheRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	store := &cache.MemoryStore{}
+	_, _, c := setup(t, store)
+
+	done := make(chan struct{})
+
+	go func() {
+		c.Run(ctx, time.Hour)
+		close(done)
+	}()
+
+	deadline := time.After(5 * time.Second)
+
+	for {
+		if keys, _ := store.Keys(ctx); len(keys) == 3 {
+			break
+		}
+
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for the first refresh")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	<-done
+}
+
+func serverURL(t *testing.T, api *fakeAPI) string {
+	t.Helper()
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
@@ -0,0 +1,222 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// ErrNotCached is returned by [Store.Load] when the store holds no entry for
+// the key.
+var ErrNotCached = errors.New("cache: entry not found")
+
+// Entry is a cached API response: the project list or the data of one project.
+type Entry struct {
+	// FetchedAt is when the response was fetched. It is zero once the entry
+	// has been invalidated, which keeps the response for offline reads but
+	// makes the next read fetch it again.
+	FetchedAt time.Time             `json:"fetchedAt"`
+	Projects  []ticktick.Project    `json:"projects,omitempty"`
+	Data      *ticktick.ProjectData `json:"data,omitempty"`
+}
+
+// Store persists the entries of a [Cache] by key. Load returns [ErrNotCached]
+// for keys that were never saved or have been deleted. Implementations must
+// be safe for concurrent use.
+type Store interface {
+	Load(ctx context.Context, key string) (*Entry, error)
+	Save(ctx context.Context, key string, entry *Entry) error
+	Delete(ctx context.Context, key string) error
+	Keys(ctx context.Context) ([]string, error)
+}
+
+// MemoryStore keeps the entries in memory. The zero value is ready to use.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+// Load returns a copy of the entry stored under key.
+func (s *MemoryStore) Load(_ context.Context, key string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, ErrNotCached
+	}
+
+	return copyEntry(&entry), nil
+}
+
+// Save stores a copy of entry under key.
+func (s *MemoryStore) Save(_ context.Context, key string, entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.entries == nil {
+		s.entries = make(map[string]Entry)
+	}
+
+	s.entries[key] = *copyEntry(entry)
+
+	return nil
+}
+
+// Delete removes the entry stored under key.
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+
+	return nil
+}
+
+// Keys returns the keys of the stored entries in sorted order.
+func (s *MemoryStore) Keys(_ context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Sorted(maps.Keys(s.entries)), nil
+}
+
+// copyEntry copies the entry and its slices, so that callers modifying a
+// returned project list or task list do not change the stored entry.
+func copyEntry(entry *Entry) *Entry {
+	c := &Entry{FetchedAt: entry.FetchedAt, Projects: slices.Clone(entry.Projects)}
+
+	if entry.Data != nil {
+		data := *entry.Data
+		data.Tasks = slices.Clone(data.Tasks)
+		data.Columns = slices.Clone(data.Columns)
+		c.Data = &data
+	}
+
+	return c
+}
+
+// FileStore keeps each entry in a JSON file of a directory, so that the cache
+// outlives the process.
+type FileStore struct {
+	dir string
+}
+
+// fileExt is the extension of the files a FileStore writes.
+const fileExt = ".json"
+
+// NewFileStore creates a FileStore backed by the directory dir. The directory
+// is created on the first Save.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// Load reads the entry stored under key.
+func (s *FileStore) Load(_ context.Context, key string) (*Entry, error) {
+	data, err := os.ReadFile(s.path(key)) //nolint:gosec // G304: the directory is chosen by the caller
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotCached
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// Save writes the entry to its file. It writes a temporary file first and
+// renames it, so an interrupted Save leaves the previous entry intact.
+func (s *FileStore) Save(_ context.Context, key string, entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// Delete removes the entry's file.
+func (s *FileStore) Delete(_ context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// Keys returns the keys of the stored entries in sorted order.
+func (s *FileStore) Keys(_ context.Context) ([]string, error) {
+	files, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+
+	for _, f := range files {
+		name, ok := strings.CutSuffix(f.Name(), fileExt)
+		if !ok || f.IsDir() {
+			continue
+		}
+
+		if key, err := url.QueryUnescape(name); err == nil {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys, nil
+}
+
+// path returns the file of the entry stored under key. Keys are escaped so
+// that any project ID makes a valid file name.
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.dir, url.QueryEscape(key)+fileExt)
+}
@@ -0,0 +1,87 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/cache"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewFileStore(filepath.Join(t.TempDir(), "cache"))
+
+	if _, err := store.Load(ctx, "projects"); !errors.Is(err, cache.ErrNotCached) {
+		t.Fatalf("expected ErrNotCached, got %v", err)
+	}
+
+	if keys, err := store.Keys(ctx); err != nil || len(keys) != 0 {
+		t.Fatalf("expected no keys, got %v, %v", keys, err)
+	}
+
+	fetched := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	entry := &cache.Entry{
+		FetchedAt: fetched,
+		Data:      &ticktick.ProjectData{Project: ticktick.Project{ID: "p/1"}, Tasks: []ticktick.Task{{ID: "t1"}}},
+	}
+
+	if err := store.Save(ctx, "project/p/1", entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := store.Save(ctx, "projects", &cache.Entry{Projects: []ticktick.Project{{ID: "p/1"}}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := store.Load(ctx, "project/p/1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !loaded.FetchedAt.Equal(fetched) || loaded.Data.Tasks[0].ID != "t1" {
+		t.Errorf("unexpected entry: %+v", loaded)
+	}
+
+	keys, err := store.Keys(ctx)
+	if err != nil || !slices.Equal(keys, []string{"project/p/1", "projects"}) {
+		t.Errorf("unexpected keys: %v, %v", keys, err)
+	}
+
+	if err := store.Delete(ctx, "project/p/1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := store.Delete(ctx, "project/p/1"); err != nil {
+		t.Errorf("expected deleting a missing entry to succeed, got %v", err)
+	}
+
+	if _, err := store.Load(ctx, "project/p/1"); !errors.Is(err, cache.ErrNotCached) {
+		t.Errorf("expected ErrNotCached, got %v", err)
+	}
+}
+
+func TestMemoryStoreCopies(t *testing.T) {
+	ctx := context.Background()
+	store := &cache.MemoryStore{}
+
+	entry := &cache.Entry{Data: &ticktick.ProjectData{Tasks: []ticktick.Task{{ID: "t1"}}}}
+	store.Save(ctx, "project/p1", entry)
+
+	entry.Data.Tasks[0].ID = "changed"
+
+	loaded, _ := store.Load(ctx, "project/p1")
+	if loaded.Data.Tasks[0].ID != "t1" {
+		t.Errorf("expected stored copy to be unaffected, got %s", loaded.Data.Tasks[0].ID)
+	}
+
+	loaded.Data.Tasks[0].ID = "changed"
+
+	if again, _ := store.Load(ctx, "project/p1"); again.Data.Tasks[0].ID != "t1" {
+		t.Errorf("expected loaded copy to be independent, got %s", again.Data.Tasks[0].ID)
+	}
+}
@@ -0,0 +1,46 @@
+package ticktick
+
+// Change describes a write made through a [Client], as passed to the
+// functions registered with [Client.OnChange].
+type Change struct {
+	// ProjectID is the project whose data changed. After UpdateTask it is the
+	// project the task belongs to now.
+	ProjectID string
+	// PreviousProjectID is the project UpdateTask moved the task out of, when
+	// known: the request named a project other than the one the returned task
+	// is in. It is empty otherwise, including for moves requested by naming
+	// only the new project.
+	PreviousProjectID string
+	// TaskID is the task that was created, updated, completed or deleted. It
+	// is empty when the project itself was created, updated or deleted.
+	TaskID string
+	// Created reports whether the write created the task or, when TaskID is
+	// empty, the project.
+	Created bool
+}
+
+// OnChange registers fn to be called after CreateTask, UpdateTask,
+// CompleteTask, DeleteTask, CreateProject, UpdateProject or DeleteProject
+// succeeds, including when they are called by other methods such as
+// CreateNote or MoveTaskToColumn. It is meant for keeping caches of API
+// responses up to date. fn is called synchronously, on the goroutine that
+// made the write, in the order the functions were registered. fn may call
+// OnChange; the functions it registers are called from the next write on.
+// Writes fn makes through the client call the registered functions again
+// before it returns.
+func (c *Client) OnChange(fn func(Change)) {
+	c.changeMu.Lock()
+	defer c.changeMu.Unlock()
+
+	c.changeFuncs = append(c.changeFuncs, fn)
+}
+
+func (c *Client) notifyChange(change Change) {
+	c.changeMu.Lock()
+	funcs := c.changeFuncs
+	c.changeMu.Unlock()
+
+	for _, fn := range funcs {
+		fn(change)
+	}
+}
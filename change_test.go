@@ -0,0 +1,72 @@
+package ticktick_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+func TestOnChange(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/fail"):
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.URL.Path == "/open/v1/task":
+			json.NewEncoder(w).Encode(ticktick.Task{ID: "t1"})
+		case r.URL.Path == "/open/v1/task/t1":
+			json.NewEncoder(w).Encode(ticktick.Task{ID: "t1", ProjectID: "p2"})
+		case r.URL.Path == "/open/v1/project" || r.URL.Path == "/open/v1/project/p3":
+			json.NewEncoder(w).Encode(ticktick.Project{ID: "p3"})
+		}
+	})
+	defer server.Close()
+
+	var changes []ticktick.Change
+
+	client.OnChange(func(c ticktick.Change) {
+		changes = append(changes, c)
+	})
+
+	ctx := context.Background()
+
+	if _, err := client.CreateTask(ctx, &ticktick.CreateTaskRequest{Title: "a", ProjectID: "p1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.MoveTaskToColumn(ctx, "p1", "t1", "c1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.CompleteTask(ctx, "p1", "t1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.DeleteTask(ctx, "p1", "fail"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, err := client.CreateProject(ctx, &ticktick.CreateProjectRequest{Name: "New"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.DeleteProject(ctx, "p3"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []ticktick.Change{
+		{ProjectID: "p1", TaskID: "t1", Created: true},
+		{ProjectID: "p2", PreviousProjectID: "p1", TaskID: "t1"},
+		{ProjectID: "p1", TaskID: "t1"},
+		{ProjectID: "p3", Created: true},
+		{ProjectID: "p3"},
+	}
+
+	if !slices.Equal(changes, expected) {
+		t.Errorf("expected %+v, got %+v", expected, changes)
+	}
+}
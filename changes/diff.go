@@ -0,0 +1,293 @@
+package changes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// Diff returns the changes from before to after: first the project events,
+// in the order of the projects in after followed by the deleted projects,
+// then the task events, in the order of the tasks in after followed by the
+// tasks that disappeared. Tasks are matched by ID across projects, so a task
+// in another project is reported as moved.
+//
+// A task that changed in several ways gets several events, e.g. [TaskMoved]
+// and [TaskUpdated]. [TaskUpdated] compares the fields users edit: title,
+// content, desc, isAllDay, startDate, dueDate, timeZone, reminders,
+// repeatFlag, priority, kind, parentId, tags and items; changes to the sort
+// order alone are not reported. [ProjectUpdated] compares color, closed,
+// groupId, viewMode, permission and kind.
+//
+// Completed tasks that disappear are not reported, because the Open API does
+// not list completed tasks; see the package documentation.
+func Diff(before, after *Snapshot) []Event {
+	old, cur := newIndex(before), newIndex(after)
+
+	return append(diffProjects(old, cur), diffTasks(old, cur)...)
+}
+
+// ResolveCompleted looks up the task of each [TaskDeleted] event and replaces
+// the event with a [TaskCompleted] if the task was completed rather than
+// deleted. Other events are returned unchanged.
+func ResolveCompleted(ctx context.Context, client *ticktick.Client, events []Event) ([]Event, error) {
+	resolved := make([]Event, 0, len(events))
+
+	for _, e := range events {
+		deleted, ok := e.(TaskDeleted)
+		if !ok {
+			resolved = append(resolved, e)
+
+			continue
+		}
+
+		task, err := client.GetTask(ctx, deleted.Task.ProjectID, deleted.Task.ID)
+		if ticktick.IsNotFound(err) {
+			resolved = append(resolved, e)
+
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("changes: get task %s: %w", deleted.Task.ID, err)
+		}
+
+		if task.Status == ticktick.TaskStatusCompleted {
+			e = TaskCompleted{Project: deleted.Project, Task: *task}
+		}
+
+		resolved = append(resolved, e)
+	}
+
+	return resolved, nil
+}
+
+// index looks up the projects and tasks of a snapshot by ID.
+type index struct {
+	snapshot *Snapshot
+	projects map[string]*ticktick.ProjectData
+	tasks    map[string]*ticktick.Task
+}
+
+func newIndex(s *Snapshot) *index {
+	if s == nil {
+		s = &Snapshot{}
+	}
+
+	idx := &index{
+		snapshot: s,
+		projects: make(map[string]*ticktick.ProjectData, len(s.Projects)),
+		tasks:    make(map[string]*ticktick.Task),
+	}
+
+	for i := range s.Projects {
+		data := &s.Projects[i]
+		idx.projects[data.Project.ID] = data
+
+		for j := range data.Tasks {
+			idx.tasks[data.Tasks[j].ID] = &data.Tasks[j]
+		}
+	}
+
+	return idx
+}
+
+// project returns the project the task belongs to, or a project holding just
+// the ID if the snapshot does not have it.
+func (idx *index) project(task *ticktick.Task) *ticktick.ProjectData {
+	if data, ok := idx.projects[task.ProjectID]; ok {
+		return data
+	}
+
+	return &ticktick.ProjectData{Project: ticktick.Project{ID: task.ProjectID}}
+}
+
+func diffProjects(old, cur *index) []Event {
+	var events []Event
+
+	for _, data := range cur.snapshot.Projects {
+		after := data.Project
+
+		prev, ok := old.projects[after.ID]
+		if !ok {
+			events = append(events, ProjectCreated{Project: after})
+
+			continue
+		}
+
+		before := prev.Project
+
+		if before.Name != after.Name {
+			events = append(events, ProjectRenamed{Project: after, OldName: before.Name})
+		}
+
+		if fields := diffFields(projectFields(), &before, &after); len(fields) > 0 {
+			events = append(events, ProjectUpdated{Before: before, After: after, Fields: fields})
+		}
+	}
+
+	for _, data := range old.snapshot.Projects {
+		if _, ok := cur.projects[data.Project.ID]; !ok {
+			events = append(events, ProjectDeleted{Project: data.Project})
+		}
+	}
+
+	return events
+}
+
+func diffTasks(old, cur *index) []Event {
+	var events []Event
+
+	for _, data := range cur.snapshot.Projects {
+		for i := range data.Tasks {
+			after := &data.Tasks[i]
+
+			before, ok := old.tasks[after.ID]
+			if !ok {
+				events = append(events, TaskCreated{Project: data.Project, Task: *after})
+
+				if after.Status == ticktick.TaskStatusCompleted {
+					events = append(events, TaskCompleted{Project: data.Project, Task: *after})
+				}
+
+				continue
+			}
+
+			events = append(events, diffTask(old.project(before), &data, before, after)...)
+		}
+	}
+
+	for _, data := range old.snapshot.Projects {
+		for _, task := range data.Tasks {
+			if _, ok := cur.tasks[task.ID]; !ok && task.Status != ticktick.TaskStatusCompleted {
+				events = append(events, TaskDeleted{Project: data.Project, Task: task})
+			}
+		}
+	}
+
+	return events
+}
+
+// diffTask returns the events of a task found in both snapshots.
+func diffTask(from, to *ticktick.ProjectData, before, after *ticktick.Task) []Event {
+	var events []Event
+
+	if before.ProjectID != after.ProjectID || before.ColumnID != after.ColumnID {
+		events = append(events, TaskMoved{
+			Task:       *after,
+			From:       from.Project,
+			To:         to.Project,
+			FromColumn: column(from, before.ColumnID),
+			ToColumn:   column(to, after.ColumnID),
+		})
+	}
+
+	if fields := diffFields(taskFields(), before, after); len(fields) > 0 {
+		events = append(events, TaskUpdated{Project: to.Project, Before: *before, After: *after, Fields: fields})
+	}
+
+	wasDone := before.Status == ticktick.TaskStatusCompleted
+	isDone := after.Status == ticktick.TaskStatusCompleted
+
+	switch {
+	case isDone && !wasDone:
+		events = append(events, TaskCompleted{Project: to.Project, Task: *after})
+	case wasDone && !isDone:
+		events = append(events, TaskReopened{Project: to.Project, Task: *after})
+	}
+
+	return events
+}
+
+// column returns the project's column with the given ID, a column holding
+// just the ID if the project does not list it, or a zero column for an empty
+// ID.
+func column(data *ticktick.ProjectData, columnID string) ticktick.Column {
+	if columnID == "" {
+		return ticktick.Column{}
+	}
+
+	if c := data.Column(columnID); c != nil {
+		return *c
+	}
+
+	return ticktick.Column{ID: columnID, ProjectID: data.Project.ID}
+}
+
+// field is a compared field with its JSON name and a function formatting its
+// value as text.
+type field[T any] struct {
+	name  string
+	value func(*T) string
+}
+
+func diffFields[T any](fields []field[T], before, after *T) []FieldChange {
+	var changes []FieldChange
+
+	for _, f := range fields {
+		if o, n := f.value(before), f.value(after); o != n {
+			changes = append(changes, FieldChange{Field: f.name, Old: o, New: n})
+		}
+	}
+
+	return changes
+}
+
+func taskFields() []field[ticktick.Task] {
+	return []field[ticktick.Task]{
+		{"title", func(t *ticktick.Task) string { return t.Title }},
+		{"content", func(t *ticktick.Task) string { return t.Content }},
+		{"desc", func(t *ticktick.Task) string { return t.Desc }},
+		{"isAllDay", func(t *ticktick.Task) string { return strconv.FormatBool(t.IsAllDay) }},
+		{"startDate", func(t *ticktick.Task) string { return formatTime(t.StartDate) }},
+		{"dueDate", func(t *ticktick.Task) string { return formatTime(t.DueDate) }},
+		{"timeZone", func(t *ticktick.Task) string { return t.TimeZone }},
+		{"reminders", func(t *ticktick.Task) string { return strings.Join(t.Reminders, ", ") }},
+		{"repeatFlag", func(t *ticktick.Task) string { return t.RepeatFlag }},
+		{"priority", func(t *ticktick.Task) string { return strconv.Itoa(t.Priority) }},
+		{"kind", func(t *ticktick.Task) string { return t.Kind }},
+		{"parentId", func(t *ticktick.Task) string { return t.ParentID }},
+		{"tags", func(t *ticktick.Task) string { return strings.Join(slices.Sorted(slices.Values(t.Tags)), ", ") }},
+		{"items", func(t *ticktick.Task) string { return formatItems(t.Items) }},
+	}
+}
+
+func projectFields() []field[ticktick.Project] {
+	return []field[ticktick.Project]{
+		{"color", func(p *ticktick.Project) string { return p.Color }},
+		{"closed", func(p *ticktick.Project) string { return strconv.FormatBool(p.Closed) }},
+		{"groupId", func(p *ticktick.Project) string { return p.GroupID }},
+		{"viewMode", func(p *ticktick.Project) string { return p.ViewMode }},
+		{"permission", func(p *ticktick.Project) string { return p.Permission }},
+		{"kind", func(p *ticktick.Project) string { return p.Kind }},
+	}
+}
+
+func formatTime(t ticktick.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatItems lists checklist items as "[x] Done item; [ ] Open item".
+func formatItems(items []ticktick.ChecklistItem) string {
+	parts := make([]string, len(items))
+
+	for i, item := range items {
+		mark := "[ ] "
+		if item.Status == ticktick.ChecklistStatusCompleted {
+			mark = "[x] "
+		}
+
+		parts[i] = mark + item.Title
+	}
+
+	return strings.Join(parts, "; ")
+}
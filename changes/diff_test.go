@@ -0,0 +1,164 @@
+package changes_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/changes"
+)
+
+func yesterday() *changes.Snapshot {
+	return &changes.Snapshot{
+		Projects: []ticktick.ProjectData{
+			{
+				Project: ticktick.Project{ID: "p1", Name: "Work", Color: "#F18181"},
+				Columns: []ticktick.Column{{ID: "c1", Name: "To Do"}, {ID: "c2", Name: "Done"}},
+				Tasks: []ticktick.Task{
+					{ID: "t1", ProjectID: "p1", ColumnID: "c1", Title: "Write report", Priority: ticktick.PriorityLow},
+					{ID: "t2", ProjectID: "p1", ColumnID: "c1", Title: "Review", Tags: []string{"b", "a"}},
+					{ID: "t3", ProjectID: "p1", Title: "Call Bob"},
+					{ID: "t4", ProjectID: "p1", Title: "Old", Status: ticktick.TaskStatusCompleted},
+				},
+			},
+			{
+				Project: ticktick.Project{ID: "p2", Name: "Home"},
+				Tasks:   []ticktick.Task{{ID: "t5", ProjectID: "p2", Title: "Clean", SortOrder: 1}},
+			},
+			{Project: ticktick.Project{ID: "p3", Name: "Gone"}},
+		},
+	}
+}
+
+func today() *changes.Snapshot {
+	due := ticktick.Time{Time: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)}
+
+	return &changes.Snapshot{
+		Projects: []ticktick.ProjectData{
+			{
+				Project: ticktick.Project{ID: "p1", Name: "Job", Color: "#000000", Closed: true},
+				Columns: []ticktick.Column{{ID: "c1", Name: "To Do"}, {ID: "c2", Name: "Done"}},
+				Tasks: []ticktick.Task{
+					{ID: "t1", ProjectID: "p1", ColumnID: "c2", Title: "Write report", DueDate: due,
+						Priority: ticktick.PriorityHigh, Status: ticktick.TaskStatusCompleted},
+					{ID: "t2", ProjectID: "p1", ColumnID: "c1", Title: "Review", Tags: []string{"a", "b"}},
+					{ID: "t6", ProjectID: "p1", Title: "Plan"},
+				},
+			},
+			{
+				Project: ticktick.Project{ID: "p2", Name: "Home"},
+				Tasks: []ticktick.Task{
+					{ID: "t5", ProjectID: "p2", Title: "Clean", SortOrder: 2},
+					{ID: "t4", ProjectID: "p2", Title: "Old"},
+				},
+			},
+			{Project: ticktick.Project{ID: "p4", Name: "New"}},
+		},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old, cur := yesterday(), today()
+	events := changes.Diff(old, cur)
+
+	work, job := old.Projects[0].Project, cur.Projects[0].Project
+	home := cur.Projects[1].Project
+
+	expected := []changes.Event{
+		changes.ProjectRenamed{Project: job, OldName: "Work"},
+		changes.ProjectUpdated{Before: work, After: job, Fields: []changes.FieldChange{
+			{Field: "color", Old: "#F18181", New: "#000000"},
+			{Field: "closed", Old: "false", New: "true"},
+		}},
+		changes.ProjectCreated{Project: cur.Projects[2].Project},
+		changes.ProjectDeleted{Project: old.Projects[2].Project},
+		changes.TaskMoved{
+			Task:       cur.Projects[0].Tasks[0],
+			From:       work,
+			To:         job,
+			FromColumn: ticktick.Column{ID: "c1", Name: "To Do"},
+			ToColumn:   ticktick.Column{ID: "c2", Name: "Done"},
+		},
+		changes.TaskUpdated{Project: job, Before: old.Projects[0].Tasks[0], After: cur.Projects[0].Tasks[0],
+			Fields: []changes.FieldChange{
+				{Field: "dueDate", Old: "", New: "2024-01-15T09:00:00Z"},
+				{Field: "priority", Old: "1", New: "5"},
+			}},
+		changes.TaskCompleted{Project: job, Task: cur.Projects[0].Tasks[0]},
+		changes.TaskCreated{Project: job, Task: cur.Projects[0].Tasks[2]},
+		changes.TaskMoved{Task: cur.Projects[1].Tasks[1], From: work, To: home},
+		changes.TaskReopened{Project: home, Task: cur.Projects[1].Tasks[1]},
+		changes.TaskDeleted{Project: work, Task: old.Projects[0].Tasks[2]},
+	}
+
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("unexpected events:\n%v\nexpected:\n%v", events, expected)
+	}
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	if events := changes.Diff(yesterday(), yesterday()); len(events) != 0 {
+		t.Errorf("expected no events, got %v", events)
+	}
+}
+
+func TestDiffNilSnapshot(t *testing.T) {
+	events := changes.Diff(nil, yesterday())
+
+	// Three projects, five tasks and the completion of the completed one.
+	if len(events) != 9 {
+		t.Errorf("expected 9 events, got %d: %v", len(events), events)
+	}
+}
+
+func TestResolveCompleted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/open/v1/project/p1/task/done":
+			json.NewEncoder(w).Encode(ticktick.Task{ID: "done", ProjectID: "p1", Title: "Done",
+				Status: ticktick.TaskStatusCompleted})
+		case "/open/v1/project/p1/task/fail":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/open/v1/project/p1/task/removed":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			// The API answers most missing tasks with 200 and an empty body.
+		}
+	}))
+	defer server.Close()
+
+	client := ticktick.NewClient("token", ticktick.WithBaseURL(server.URL))
+	project := ticktick.Project{ID: "p1", Name: "Work"}
+
+	events := []changes.Event{
+		changes.ProjectCreated{Project: project},
+		changes.TaskDeleted{Project: project, Task: ticktick.Task{ID: "done", ProjectID: "p1"}},
+		changes.TaskDeleted{Project: project, Task: ticktick.Task{ID: "gone", ProjectID: "p1"}},
+		changes.TaskDeleted{Project: project, Task: ticktick.Task{ID: "removed", ProjectID: "p1"}},
+	}
+
+	resolved, err := changes.ResolveCompleted(context.Background(), client, events)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	completed, ok := resolved[1].(changes.TaskCompleted)
+	if !ok || completed.Task.Title != "Done" || completed.Project.Name != "Work" {
+		t.Errorf("expected a completion, got %v", resolved[1])
+	}
+
+	if !reflect.DeepEqual(resolved[0], events[0]) || !reflect.DeepEqual(resolved[2], events[2]) ||
+		!reflect.DeepEqual(resolved[3], events[3]) {
+		t.Errorf("expected other events unchanged, got %v", resolved)
+	}
+
+	failing := []changes.Event{changes.TaskDeleted{Task: ticktick.Task{ID: "fail", ProjectID: "p1"}}}
+	if _, err := changes.ResolveCompleted(context.Background(), client, failing); err == nil {
+		t.Error("expected an error")
+	}
+}
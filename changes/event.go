@@ -0,0 +1,172 @@
+package changes
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// Event is a change found by [Diff]. It is one of [ProjectCreated],
+// [ProjectDeleted], [ProjectRenamed], [ProjectUpdated], [TaskCreated],
+// [TaskUpdated], [TaskMoved], [TaskCompleted], [TaskReopened] and
+// [TaskDeleted]. String describes the change in a line of text.
+type Event interface {
+	fmt.Stringer
+
+	event()
+}
+
+// FieldChange is a field whose value differs between two snapshots. Field is
+// the field's JSON name, such as "dueDate"; Old and New are its values as
+// text, empty for unset values.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s %q -> %q", c.Field, c.Old, c.New)
+}
+
+// ProjectCreated is a project that appeared.
+type ProjectCreated struct {
+	Project ticktick.Project
+}
+
+// ProjectDeleted is a project that disappeared.
+type ProjectDeleted struct {
+	Project ticktick.Project
+}
+
+// ProjectRenamed is a project whose name changed. Project holds the new state.
+type ProjectRenamed struct {
+	Project ticktick.Project
+	OldName string
+}
+
+// ProjectUpdated is a project whose other settings, such as its color or
+// whether it is closed, changed.
+type ProjectUpdated struct {
+	Before ticktick.Project
+	After  ticktick.Project
+	Fields []FieldChange
+}
+
+// TaskCreated is a task that appeared in Project.
+type TaskCreated struct {
+	Project ticktick.Project
+	Task    ticktick.Task
+}
+
+// TaskUpdated is a task whose fields changed. Moves, completion and reopening
+// are reported by their own events and are not listed in Fields.
+type TaskUpdated struct {
+	Project ticktick.Project
+	Before  ticktick.Task
+	After   ticktick.Task
+	Fields  []FieldChange
+}
+
+// TaskMoved is a task that moved to another project or kanban column. The
+// columns are zero for projects without columns.
+type TaskMoved struct {
+	Task       ticktick.Task
+	From       ticktick.Project
+	To         ticktick.Project
+	FromColumn ticktick.Column
+	ToColumn   ticktick.Column
+}
+
+// TaskCompleted is a task that was completed. Task holds the completed task.
+type TaskCompleted struct {
+	Project ticktick.Project
+	Task    ticktick.Task
+}
+
+// TaskReopened is a completed task that was marked open again.
+type TaskReopened struct {
+	Project ticktick.Project
+	Task    ticktick.Task
+}
+
+// TaskDeleted is an open task that disappeared, because it was deleted or,
+// unless the event went through [ResolveCompleted], completed. Task holds the
+// task as it was in the older snapshot.
+type TaskDeleted struct {
+	Project ticktick.Project
+	Task    ticktick.Task
+}
+
+func (ProjectCreated) event() {}
+func (ProjectDeleted) event() {}
+func (ProjectRenamed) event() {}
+func (ProjectUpdated) event() {}
+func (TaskCreated) event()    {}
+func (TaskUpdated) event()    {}
+func (TaskMoved) event()      {}
+func (TaskCompleted) event()  {}
+func (TaskReopened) event()   {}
+func (TaskDeleted) event()    {}
+
+func (e ProjectCreated) String() string {
+	return fmt.Sprintf("project %q created", e.Project.Name)
+}
+
+func (e ProjectDeleted) String() string {
+	return fmt.Sprintf("project %q deleted", e.Project.Name)
+}
+
+func (e ProjectRenamed) String() string {
+	return fmt.Sprintf("project %q renamed to %q", e.OldName, e.Project.Name)
+}
+
+func (e ProjectUpdated) String() string {
+	return fmt.Sprintf("project %q updated: %s", e.After.Name, joinFields(e.Fields))
+}
+
+func (e TaskCreated) String() string {
+	return fmt.Sprintf("task %q created in %q", e.Task.Title, e.Project.Name)
+}
+
+func (e TaskUpdated) String() string {
+	return fmt.Sprintf("task %q in %q updated: %s", e.After.Title, e.Project.Name, joinFields(e.Fields))
+}
+
+func (e TaskMoved) String() string {
+	from, to := place(e.From, e.FromColumn), place(e.To, e.ToColumn)
+
+	return fmt.Sprintf("task %q moved from %s to %s", e.Task.Title, from, to)
+}
+
+func (e TaskCompleted) String() string {
+	return fmt.Sprintf("task %q completed in %q", e.Task.Title, e.Project.Name)
+}
+
+func (e TaskReopened) String() string {
+	return fmt.Sprintf("task %q reopened in %q", e.Task.Title, e.Project.Name)
+}
+
+func (e TaskDeleted) String() string {
+	return fmt.Sprintf("task %q deleted from %q", e.Task.Title, e.Project.Name)
+}
+
+func joinFields(fields []FieldChange) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = f.String()
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// place names a project and, if the task is in one, its column.
+func place(project ticktick.Project, column ticktick.Column) string {
+	if column.ID == "" {
+		return fmt.Sprintf("%q", project.Name)
+	}
+
+	return fmt.Sprintf("%q / %q", project.Name, cmp.Or(column.Name, column.ID))
+}
@@ -0,0 +1,55 @@
+package changes_test
+
+import (
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/changes"
+)
+
+func TestEventString(t *testing.T) {
+	work := ticktick.Project{ID: "p1", Name: "Work"}
+	home := ticktick.Project{ID: "p2", Name: "Home"}
+	task := ticktick.Task{ID: "t1", Title: "Pay rent"}
+
+	tests := []struct {
+		event    changes.Event
+		expected string
+	}{
+		{changes.ProjectCreated{Project: work}, `project "Work" created`},
+		{changes.ProjectDeleted{Project: work}, `project "Work" deleted`},
+		{changes.ProjectRenamed{Project: work, OldName: "Job"}, `project "Job" renamed to "Work"`},
+		{
+			changes.ProjectUpdated{After: work, Fields: []changes.FieldChange{
+				{Field: "color", Old: "", New: "#F18181"},
+				{Field: "closed", Old: "false", New: "true"},
+			}},
+			`project "Work" updated: color "" -> "#F18181", closed "false" -> "true"`,
+		},
+		{changes.TaskCreated{Project: work, Task: task}, `task "Pay rent" created in "Work"`},
+		{
+			changes.TaskUpdated{Project: work, After: task, Fields: []changes.FieldChange{
+				{Field: "dueDate", Old: "2024-01-15T09:00:00Z", New: ""},
+			}},
+			`task "Pay rent" in "Work" updated: dueDate "2024-01-15T09:00:00Z" -> ""`,
+		},
+		{changes.TaskMoved{Task: task, From: work, To: home}, `task "Pay rent" moved from "Work" to "Home"`},
+		{
+			changes.TaskMoved{
+				Task: task, From: work, To: work,
+				FromColumn: ticktick.Column{ID: "c1", Name: "To Do"},
+				ToColumn:   ticktick.Column{ID: "c2"},
+			},
+			`task "Pay rent" moved from "Work" / "To Do" to "Work" / "c2"`,
+		},
+		{changes.TaskCompleted{Project: home, Task: task}, `task "Pay rent" completed in "Home"`},
+		{changes.TaskReopened{Project: home, Task: task}, `task "Pay rent" reopened in "Home"`},
+		{changes.TaskDeleted{Project: home, Task: task}, `task "Pay rent" deleted from "Home"`},
+	}
+
+	for _, tt := range tests {
+		if got := tt.event.String(); got != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, got)
+		}
+	}
+}
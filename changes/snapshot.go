@@ -0,0 +1,60 @@
+// Package changes tells what changed in a TickTick account between two
+// points in time.
+//
+// A [Snapshot] holds every project with its tasks. [Diff] compares two
+// snapshots and returns typed events such as [TaskCreated], [TaskUpdated]
+// with the changed fields, [TaskMoved] or [ProjectRenamed], for driving
+// notifications and audit logs:
+//
+//	archive, err := backup.Read(f) // yesterday's backup
+//	today, err := changes.Take(ctx, client)
+//	yesterday := changes.FromArchive(archive)
+//
+//	events, err := changes.ResolveCompleted(ctx, client, changes.Diff(yesterday, today))
+//	for _, e := range events {
+//		fmt.Println(e) // e.g. task "Pay rent" in "Home" updated: dueDate "2024-01-15T09:00:00Z" -> ""
+//	}
+//
+// The Open API lists only open tasks, so a task that was completed disappears
+// from the next snapshot just like a deleted one. Diff reports both as
+// [TaskDeleted]; [ResolveCompleted] looks the tasks up and turns those that
+// were completed into [TaskCompleted].
+package changes
+
+import (
+	"context"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/backup"
+)
+
+// Snapshot is the state of an account at one point in time. It can be stored
+// with encoding/json and compared with a later snapshot by [Diff].
+type Snapshot struct {
+	TakenAt  time.Time              `json:"takenAt"`
+	Projects []ticktick.ProjectData `json:"projects"`
+}
+
+// Take fetches a snapshot of every project, including closed ones and the
+// Inbox, with their open tasks and columns.
+func Take(ctx context.Context, client *ticktick.Client) (*Snapshot, error) {
+	archive, err := backup.Snapshot(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromArchive(archive), nil
+}
+
+// FromArchive returns the snapshot held by a backup archive, so that backups
+// taken earlier can be compared with the current state of the account.
+func FromArchive(archive *backup.Archive) *Snapshot {
+	s := &Snapshot{TakenAt: archive.CreatedAt}
+
+	for _, p := range archive.Projects {
+		s.Projects = append(s.Projects, ticktick.ProjectData{Project: p.Project, Tasks: p.Tasks, Columns: p.Columns})
+	}
+
+	return s
+}
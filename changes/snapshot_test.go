@@ -0,0 +1,64 @@
+package changes_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/backup"
+	"github.com/slavkluev/go-ticktick/changes"
+)
+
+func TestTake(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/open/v1/project":
+			json.NewEncoder(w).Encode([]ticktick.Project{{ID: "p1", Name: "Work"}})
+		case "/open/v1/project/p1/data":
+			json.NewEncoder(w).Encode(ticktick.ProjectData{
+				Tasks:   []ticktick.Task{{ID: "t1", ProjectID: "p1"}},
+				Columns: []ticktick.Column{{ID: "c1"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := ticktick.NewClient("token", ticktick.WithBaseURL(server.URL))
+
+	s, err := changes.Take(context.Background(), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s.TakenAt.IsZero() || len(s.Projects) != 1 || s.Projects[0].Project.Name != "Work" ||
+		len(s.Projects[0].Tasks) != 1 || len(s.Projects[0].Columns) != 1 {
+		t.Errorf("unexpected snapshot: %+v", s)
+	}
+}
+
+func TestFromArchive(t *testing.T) {
+	created := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	archive := &backup.Archive{
+		Version:   backup.Version,
+		CreatedAt: created,
+		Projects: []backup.ProjectBackup{{
+			Project: ticktick.Project{ID: "p1"},
+			Columns: []ticktick.Column{{ID: "c1"}},
+			Tasks:   []ticktick.Task{{ID: "t1"}},
+			Folder:  "Folder",
+		}},
+	}
+
+	s := changes.FromArchive(archive)
+
+	if !s.TakenAt.Equal(created) || len(s.Projects) != 1 || s.Projects[0].Project.ID != "p1" ||
+		s.Projects[0].Columns[0].ID != "c1" || s.Projects[0].Tasks[0].ID != "t1" {
+		t.Errorf("unexpected snapshot: %+v", s)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 )
 
 // DefaultBaseURL is the default base URL of the TickTick API.
@@ -18,6 +19,21 @@ type Client struct {
 	httpClient  *http.Client
 	baseURL     string
 	accessToken string
+	validate    bool
+
+	// noteMu guards noteLocks, the mutexes serializing AppendToNote
+	// read-modify-write cycles per note ID. Entries are removed once no
+	// append holds or waits for them.
+	noteMu    sync.Mutex
+	noteLocks map[string]*noteLock
+
+	// inboxMu guards inboxID, the Inbox project ID once discovered.
+	inboxMu sync.Mutex
+	inboxID string
+
+	// changeMu guards changeFuncs, the functions registered with OnChange.
+	changeMu    sync.Mutex
+	changeFuncs []func(Change)
 }
 
 // Option configures a Client.
@@ -37,12 +53,22 @@ func WithBaseURL(baseURL string) Option {
 	}
 }
 
+// WithValidation enables or disables client-side request validation.
+// Validation is enabled by default; when disabled, requests are sent to
+// the API as-is and any mistakes are reported by the server.
+func WithValidation(enabled bool) Option {
+	return func(c *Client) {
+		c.validate = enabled
+	}
+}
+
 // NewClient creates a new TickTick API client with the given access token.
 func NewClient(accessToken string, opts ...Option) *Client {
 	c := &Client{
 		httpClient:  http.DefaultClient,
 		baseURL:     DefaultBaseURL,
 		accessToken: accessToken,
+		validate:    true,
 	}
 
 	for _, opt := range opts {
@@ -62,6 +88,36 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("ticktick: HTTP %d: %s", e.StatusCode, e.Body)
 }
 
+// emptyResponseBody is the Body of the [*Error] returned for a successful
+// response without a body.
+const emptyResponseBody = "empty response body"
+
+// IsNotFound reports whether err is an [*Error] for a missing resource: a 404
+// response, or a 200 response with an empty body, which is what the API
+// returns for most missing tasks and projects.
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusNotFound ||
+		(apiErr.StatusCode == http.StatusOK && apiErr.Body == emptyResponseBody)
+}
+
+type validator interface {
+	Validate() error
+}
+
+// validateRequest runs the request's Validate method unless validation is disabled.
+func (c *Client) validateRequest(req validator) error {
+	if !c.validate {
+		return nil
+	}
+
+	return req.Validate()
+}
+
 func (c *Client) do(req *http.Request, v any) error {
 	req.Header.Set("Authorization", "Bearer "+c.accessToken)
 
@@ -95,7 +151,7 @@ func (c *Client) do(req *http.Request, v any) error {
 		if errors.Is(decErr, io.EOF) {
 			return &Error{
 				StatusCode: resp.StatusCode,
-				Body:       "empty response body",
+				Body:       emptyResponseBody,
 			}
 		}
 
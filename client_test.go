@@ -3,6 +3,7 @@ package ticktick_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -156,6 +157,29 @@ func TestEmptyResponseBody(t *testing.T) {
 	}
 }
 
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"404", &ticktick.Error{StatusCode: http.StatusNotFound}, true},
+		{"200 empty body", &ticktick.Error{StatusCode: http.StatusOK, Body: "empty response body"}, true},
+		{"wrapped", fmt.Errorf("get task: %w", &ticktick.Error{StatusCode: http.StatusNotFound}), true},
+		{"500", &ticktick.Error{StatusCode: http.StatusInternalServerError}, false},
+		{"other", errors.New("network down"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ticktick.IsNotFound(tt.err); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
 func TestErrorStatusBoundaries(t *testing.T) {
 	tests := []struct {
 		name      string
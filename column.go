@@ -0,0 +1,75 @@
+package ticktick
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// ColumnTasks holds a kanban column together with the tasks in it.
+type ColumnTasks struct {
+	Column Column
+	Tasks  []Task
+}
+
+// TasksByColumn groups the project's tasks by kanban column. Columns are ordered
+// by SortOrder and tasks within each column by SortOrder. Every column is
+// returned, even if empty. Tasks whose ColumnID does not match any column are
+// collected in a final group with a zero Column; it is omitted when empty.
+func (d *ProjectData) TasksByColumn() []ColumnTasks {
+	columns := slices.Clone(d.Columns)
+	slices.SortStableFunc(columns, func(a, b Column) int {
+		return cmp.Compare(a.SortOrder, b.SortOrder)
+	})
+
+	groups := make([]ColumnTasks, len(columns))
+	index := make(map[string]int, len(columns))
+
+	for i, col := range columns {
+		groups[i] = ColumnTasks{Column: col}
+		index[col.ID] = i
+	}
+
+	var unassigned []Task
+
+	for _, task := range d.Tasks {
+		i, ok := index[task.ColumnID]
+		if !ok {
+			unassigned = append(unassigned, task)
+
+			continue
+		}
+
+		groups[i].Tasks = append(groups[i].Tasks, task)
+	}
+
+	if len(unassigned) > 0 {
+		groups = append(groups, ColumnTasks{Tasks: unassigned})
+	}
+
+	for i := range groups {
+		groups[i].Tasks = sortedTasks(groups[i].Tasks)
+	}
+
+	return groups
+}
+
+// Column returns the column with the given ID, or nil if the project has no such column.
+func (d *ProjectData) Column(columnID string) *Column {
+	for i := range d.Columns {
+		if d.Columns[i].ID == columnID {
+			return &d.Columns[i]
+		}
+	}
+
+	return nil
+}
+
+// MoveTaskToColumn moves a task to another kanban column of the same project.
+func (c *Client) MoveTaskToColumn(ctx context.Context, projectID, taskID, columnID string) (*Task, error) {
+	return c.UpdateTask(ctx, taskID, &UpdateTaskRequest{
+		ID:        taskID,
+		ProjectID: projectID,
+		ColumnID:  String(columnID),
+	})
+}
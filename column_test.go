@@ -0,0 +1,118 @@
+package ticktick_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+func TestTasksByColumn(t *testing.T) {
+	data := ticktick.ProjectData{
+		Columns: []ticktick.Column{
+			{ID: "done", Name: "Done", SortOrder: 200},
+			{ID: "todo", Name: "To Do", SortOrder: 100},
+			{ID: "empty", Name: "Empty", SortOrder: 300},
+		},
+		Tasks: []ticktick.Task{
+			{ID: "t1", ColumnID: "todo", SortOrder: 20},
+			{ID: "t2", ColumnID: "done", SortOrder: 10},
+			{ID: "t3", ColumnID: "todo", SortOrder: 10},
+			{ID: "t4", ColumnID: "gone"},
+		},
+	}
+
+	groups := data.TasksByColumn()
+
+	if len(groups) != 4 {
+		t.Fatalf("expected 4 groups, got %d", len(groups))
+	}
+
+	wantColumns := []string{"todo", "done", "empty", ""}
+	for i, want := range wantColumns {
+		if groups[i].Column.ID != want {
+			t.Errorf("group %d: expected column %q, got %q", i, want, groups[i].Column.ID)
+		}
+	}
+
+	if len(groups[0].Tasks) != 2 || groups[0].Tasks[0].ID != "t3" || groups[0].Tasks[1].ID != "t1" {
+		t.Errorf("unexpected todo tasks: %+v", groups[0].Tasks)
+	}
+
+	if len(groups[2].Tasks) != 0 {
+		t.Errorf("expected empty column, got %+v", groups[2].Tasks)
+	}
+
+	if len(groups[3].Tasks) != 1 || groups[3].Tasks[0].ID != "t4" {
+		t.Errorf("unexpected unassigned tasks: %+v", groups[3].Tasks)
+	}
+}
+
+func TestProjectDataColumn(t *testing.T) {
+	data := ticktick.ProjectData{Columns: []ticktick.Column{{ID: "col1", Name: "Backlog"}}}
+
+	if col := data.Column("col1"); col == nil || col.Name != "Backlog" {
+		t.Errorf("unexpected column: %+v", col)
+	}
+
+	if col := data.Column("missing"); col != nil {
+		t.Errorf("expected nil, got %+v", col)
+	}
+}
+
+func TestMoveTaskToColumn(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+
+		if r.URL.Path != "/open/v1/task/task1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var req ticktick.UpdateTaskRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		if req.ID != "task1" || req.ProjectID != "proj1" {
+			t.Errorf("unexpected ids: %s / %s", req.ID, req.ProjectID)
+		}
+
+		if req.ColumnID == nil || *req.ColumnID != "col2" {
+			t.Errorf("expected columnId col2, got %v", req.ColumnID)
+		}
+
+		json.NewEncoder(w).Encode(ticktick.Task{ID: "task1", ProjectID: "proj1", ColumnID: "col2"})
+	})
+	defer server.Close()
+
+	task, err := client.MoveTaskToColumn(context.Background(), "proj1", "task1", "col2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.ColumnID != "col2" {
+		t.Errorf("expected column col2, got %s", task.ColumnID)
+	}
+}
+
+func TestGetProjectDataColumnID(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"project":{"id":"proj1"},"tasks":[{"id":"t1","columnId":"col1"}],"columns":[{"id":"col1"}]}`))
+	})
+	defer server.Close()
+
+	data, err := client.GetProjectData(context.Background(), "proj1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	groups := data.TasksByColumn()
+	if len(groups) != 1 || len(groups[0].Tasks) != 1 || groups[0].Tasks[0].ID != "t1" {
+		t.Errorf("unexpected groups: %+v", groups)
+	}
+}
@@ -0,0 +1,62 @@
+package content
+
+import (
+	"strings"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// ChecklistItems converts the document's checkbox lines into checklist item
+// requests, preserving their completion state. Nesting is flattened because
+// TickTick checklists have a single level.
+func (d *Document) ChecklistItems() []ticktick.CreateChecklistItemRequest {
+	var items []ticktick.CreateChecklistItemRequest
+
+	for _, b := range d.Checkboxes() {
+		items = append(items, checklistItem(b.Text, b.Checked))
+	}
+
+	return items
+}
+
+// FormatChecklist renders checklist items as markdown checkbox lines, the
+// inverse of [Document.ChecklistItems].
+func FormatChecklist(items []ticktick.ChecklistItem) string {
+	var b strings.Builder
+
+	for _, item := range items {
+		b.WriteString(checkboxLine(item.Title, item.Status == ticktick.ChecklistStatusCompleted))
+	}
+
+	return b.String()
+}
+
+// FormatChecklistRequests renders checklist item requests as markdown checkbox
+// lines. Items without a status are rendered unchecked.
+func FormatChecklistRequests(items []ticktick.CreateChecklistItemRequest) string {
+	var b strings.Builder
+
+	for _, item := range items {
+		done := item.Status != nil && *item.Status == ticktick.ChecklistStatusCompleted
+		b.WriteString(checkboxLine(item.Title, done))
+	}
+
+	return b.String()
+}
+
+func checklistItem(title string, done bool) ticktick.CreateChecklistItemRequest {
+	status := ticktick.ChecklistStatusNormal
+	if done {
+		status = ticktick.ChecklistStatusCompleted
+	}
+
+	return ticktick.CreateChecklistItemRequest{Title: title, Status: ticktick.Int(status)}
+}
+
+func checkboxLine(title string, done bool) string {
+	if done {
+		return "- [x] " + title + "\n"
+	}
+
+	return "- [ ] " + title + "\n"
+}
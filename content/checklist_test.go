@@ -0,0 +1,43 @@
+package content_test
+
+import (
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/content"
+)
+
+func TestDocumentChecklistItems(t *testing.T) {
+	items := content.Parse(note).ChecklistItems()
+
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+
+	if items[0].Title != "Write tests" || *items[0].Status != ticktick.ChecklistStatusNormal {
+		t.Errorf("unexpected first item: %+v", items[0])
+	}
+
+	if items[1].Title != "Parser" || *items[1].Status != ticktick.ChecklistStatusCompleted {
+		t.Errorf("unexpected second item: %+v", items[1])
+	}
+}
+
+func TestFormatChecklist(t *testing.T) {
+	got := content.FormatChecklist([]ticktick.ChecklistItem{
+		{Title: "One", Status: ticktick.ChecklistStatusCompleted},
+		{Title: "Two"},
+	})
+
+	if got != "- [x] One\n- [ ] Two\n" {
+		t.Errorf("unexpected checklist: %q", got)
+	}
+}
+
+func TestFormatChecklistRequestsRoundTrip(t *testing.T) {
+	const text = "- [ ] One\n- [x] Two\n"
+
+	if got := content.FormatChecklistRequests(content.Parse(text).ChecklistItems()); got != text {
+		t.Errorf("expected round trip %q, got %q", text, got)
+	}
+}
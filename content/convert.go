@@ -0,0 +1,111 @@
+package content
+
+import (
+	"context"
+	"strings"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// ChecklistRequest returns the update that turns a TEXT task into a CHECKLIST
+// task. Every bullet, numbered or checkbox line of Content becomes a checklist
+// item, ticked if its checkbox was; the task's existing items are kept first.
+// The remaining lines of Content are moved to Desc, after any existing Desc.
+func ChecklistRequest(task *ticktick.Task) *ticktick.UpdateTaskRequest {
+	items := itemRequests(task.Items)
+
+	var rest []string
+
+	inCode := false
+
+	for line := range strings.Lines(strings.ReplaceAll(task.Content, "\r\n", "\n")) {
+		line = strings.TrimSuffix(line, "\n")
+
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+		}
+
+		block, ok := parseBlockLine(line)
+		if inCode || !ok || (block.Kind != ListItem && block.Kind != Checkbox) || block.Text == "" {
+			rest = append(rest, line)
+
+			continue
+		}
+
+		items = append(items, checklistItem(block.Text, block.Checked))
+	}
+
+	return &ticktick.UpdateTaskRequest{
+		ID:        task.ID,
+		ProjectID: task.ProjectID,
+		Kind:      ticktick.String(ticktick.TaskKindChecklist),
+		Content:   ticktick.String(""),
+		Desc:      ticktick.String(joinParagraphs(task.Desc, strings.Join(rest, "\n"))),
+		Items:     items,
+		Extra:     task.Extra,
+	}
+}
+
+// TextRequest returns the update that turns a CHECKLIST task into a TEXT task.
+// Desc, Content and the checklist items, rendered as "- [ ]" and "- [x]"
+// lines, are joined into Content, and the items are removed.
+func TextRequest(task *ticktick.Task) *ticktick.UpdateTaskRequest {
+	return &ticktick.UpdateTaskRequest{
+		ID:        task.ID,
+		ProjectID: task.ProjectID,
+		Kind:      ticktick.String(ticktick.TaskKindText),
+		Content:   ticktick.String(joinParagraphs(task.Desc, task.Content, FormatChecklist(task.Items))),
+		Desc:      ticktick.String(""),
+		Items:     []ticktick.CreateChecklistItemRequest{},
+		Extra:     task.Extra,
+	}
+}
+
+// ConvertToChecklist converts a TEXT task into a CHECKLIST task as described
+// by [ChecklistRequest] and returns the updated task.
+func ConvertToChecklist(ctx context.Context, client *ticktick.Client, task *ticktick.Task) (*ticktick.Task, error) {
+	return client.UpdateTask(ctx, task.ID, ChecklistRequest(task))
+}
+
+// ConvertToText converts a CHECKLIST task into a TEXT task as described by
+// [TextRequest] and returns the updated task.
+func ConvertToText(ctx context.Context, client *ticktick.Client, task *ticktick.Task) (*ticktick.Task, error) {
+	return client.UpdateTask(ctx, task.ID, TextRequest(task))
+}
+
+// itemRequests converts existing checklist items to requests, keeping their
+// completion state and dates.
+func itemRequests(items []ticktick.ChecklistItem) []ticktick.CreateChecklistItemRequest {
+	reqs := make([]ticktick.CreateChecklistItemRequest, 0, len(items))
+
+	for _, item := range items {
+		req := checklistItem(item.Title, item.Status == ticktick.ChecklistStatusCompleted)
+		req.SortOrder = ticktick.Int64(item.SortOrder)
+
+		if !item.StartDate.IsZero() {
+			req.StartDate = ticktick.NewTime(item.StartDate.Time)
+			req.IsAllDay = ticktick.Bool(item.IsAllDay)
+		}
+
+		if !item.CompletedTime.IsZero() {
+			req.CompletedTime = ticktick.NewTime(item.CompletedTime.Time)
+		}
+
+		reqs = append(reqs, req)
+	}
+
+	return reqs
+}
+
+// joinParagraphs joins the non-blank parts with blank lines between them.
+func joinParagraphs(parts ...string) string {
+	var kept []string
+
+	for _, p := range parts {
+		if p = strings.Trim(p, "\n"); strings.TrimSpace(p) != "" {
+			kept = append(kept, p)
+		}
+	}
+
+	return strings.Join(kept, "\n\n")
+}
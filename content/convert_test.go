@@ -0,0 +1,135 @@
+package content_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/content"
+)
+
+func TestChecklistRequest(t *testing.T) {
+	task := &ticktick.Task{
+		ID:        "t1",
+		ProjectID: "p1",
+		Desc:      "Existing",
+		Content:   "Shopping\n- [x] Milk\n- Bread\n1. Eggs\n```\n- not an item\n```",
+		Items:     []ticktick.ChecklistItem{{Title: "Butter", SortOrder: 5}},
+	}
+
+	req := content.ChecklistRequest(task)
+
+	if *req.Kind != ticktick.TaskKindChecklist || *req.Content != "" {
+		t.Errorf("unexpected kind or content: %q, %q", *req.Kind, *req.Content)
+	}
+
+	want := []struct {
+		title  string
+		status int
+	}{
+		{"Butter", ticktick.ChecklistStatusNormal},
+		{"Milk", ticktick.ChecklistStatusCompleted},
+		{"Bread", ticktick.ChecklistStatusNormal},
+		{"Eggs", ticktick.ChecklistStatusNormal},
+	}
+
+	if len(req.Items) != len(want) {
+		t.Fatalf("expected %d items, got %d", len(want), len(req.Items))
+	}
+
+	for i, w := range want {
+		if req.Items[i].Title != w.title || *req.Items[i].Status != w.status {
+			t.Errorf("item %d: expected %s/%d, got %s/%d",
+				i, w.title, w.status, req.Items[i].Title, *req.Items[i].Status)
+		}
+	}
+
+	if *req.Items[0].SortOrder != 5 {
+		t.Errorf("expected existing item sort order 5, got %d", *req.Items[0].SortOrder)
+	}
+
+	if *req.Desc != "Existing\n\nShopping\n```\n- not an item\n```" {
+		t.Errorf("unexpected desc: %q", *req.Desc)
+	}
+}
+
+func TestTextRequest(t *testing.T) {
+	task := &ticktick.Task{
+		ID:        "t1",
+		ProjectID: "p1",
+		Desc:      "Shopping",
+		Kind:      ticktick.TaskKindChecklist,
+		Items: []ticktick.ChecklistItem{
+			{Title: "Milk", Status: ticktick.ChecklistStatusCompleted},
+			{Title: "Bread"},
+		},
+	}
+
+	req := content.TextRequest(task)
+
+	if *req.Kind != ticktick.TaskKindText || *req.Desc != "" {
+		t.Errorf("unexpected kind or desc: %q, %q", *req.Kind, *req.Desc)
+	}
+
+	if *req.Content != "Shopping\n\n- [x] Milk\n- [ ] Bread" {
+		t.Errorf("unexpected content: %q", *req.Content)
+	}
+
+	if req.Items == nil || len(req.Items) != 0 {
+		t.Errorf("expected empty non-nil items, got %v", req.Items)
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	task := &ticktick.Task{Content: "- [x] Milk\n- [ ] Bread\n"}
+
+	req := content.ChecklistRequest(task)
+
+	back := content.FormatChecklistRequests(req.Items)
+	if back != task.Content {
+		t.Errorf("expected %q, got %q", task.Content, back)
+	}
+}
+
+func TestConvertToText(t *testing.T) {
+	var body map[string]json.RawMessage
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open/v1/task/t1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		json.NewEncoder(w).Encode(ticktick.Task{ID: "t1", Kind: ticktick.TaskKindText})
+	}))
+	defer server.Close()
+
+	client := ticktick.NewClient("token", ticktick.WithBaseURL(server.URL))
+
+	task, err := content.ConvertToText(context.Background(), client, &ticktick.Task{
+		ID:        "t1",
+		ProjectID: "p1",
+		Items:     []ticktick.ChecklistItem{{Title: "Milk"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.Kind != ticktick.TaskKindText {
+		t.Errorf("expected kind TEXT, got %q", task.Kind)
+	}
+
+	if string(body["items"]) != "[]" {
+		t.Errorf("expected items to be cleared, got %s", body["items"])
+	}
+
+	if string(body["content"]) != `"- [ ] Milk"` {
+		t.Errorf("unexpected content: %s", body["content"])
+	}
+}
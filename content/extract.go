@@ -0,0 +1,89 @@
+package content
+
+import (
+	"slices"
+	"strings"
+)
+
+// LinkRef is a link or embedded image found in content.
+type LinkRef struct {
+	Text string
+	URL  string
+	// Image reports whether the link is an embedded image. TickTick embeds
+	// attachments this way.
+	Image bool
+}
+
+// Links returns every link and image in the document, in order. Bare URLs are
+// included with Text equal to URL.
+func (d *Document) Links() []LinkRef {
+	var links []LinkRef
+
+	d.walkInline(func(n Inline) {
+		switch n.Kind {
+		case Link:
+			links = append(links, LinkRef{Text: n.Text, URL: n.URL})
+		case Image:
+			links = append(links, LinkRef{Text: n.Text, URL: n.URL, Image: true})
+		case Text, Code, Emphasis, Strong, Strikethrough, Mention:
+		}
+	})
+
+	return links
+}
+
+// URLs returns the distinct URLs of the document's links and images, in order
+// of first appearance.
+func (d *Document) URLs() []string {
+	var urls []string
+
+	for _, l := range d.Links() {
+		if l.URL != "" && !slices.Contains(urls, l.URL) {
+			urls = append(urls, l.URL)
+		}
+	}
+
+	return urls
+}
+
+// Attachments returns the embedded images whose URL is not absolute, which is
+// how TickTick references files attached to a task.
+func (d *Document) Attachments() []LinkRef {
+	var attachments []LinkRef
+
+	for _, l := range d.Links() {
+		if l.Image && !strings.Contains(l.URL, "://") {
+			attachments = append(attachments, l)
+		}
+	}
+
+	return attachments
+}
+
+// Mentions returns the distinct @mentions in the document, without the '@',
+// in order of first appearance.
+func (d *Document) Mentions() []string {
+	var mentions []string
+
+	d.walkInline(func(n Inline) {
+		if n.Kind == Mention && !slices.Contains(mentions, n.Text) {
+			mentions = append(mentions, n.Text)
+		}
+	})
+
+	return mentions
+}
+
+// walkInline calls fn for every inline node in the document, depth first.
+func (d *Document) walkInline(fn func(Inline)) {
+	for _, b := range d.Blocks {
+		walkInline(b.Inline, fn)
+	}
+}
+
+func walkInline(nodes []Inline, fn func(Inline)) {
+	for _, n := range nodes {
+		fn(n)
+		walkInline(n.Children, fn)
+	}
+}
@@ -0,0 +1,37 @@
+package content_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick/content"
+)
+
+func TestLinksAndURLs(t *testing.T) {
+	doc := content.Parse("[a](https://a.example) and https://a.example again\n\n![pic](https://cdn.example/p.png)")
+
+	links := doc.Links()
+	if len(links) != 3 || !links[2].Image || links[0].Text != "a" {
+		t.Errorf("unexpected links: %+v", links)
+	}
+
+	if got := strings.Join(doc.URLs(), ","); got != "https://a.example,https://cdn.example/p.png" {
+		t.Errorf("unexpected URLs: %s", got)
+	}
+}
+
+func TestAttachments(t *testing.T) {
+	attachments := content.Parse(note).Attachments()
+
+	if len(attachments) != 1 || attachments[0].URL != "6565/screenshot.png" {
+		t.Errorf("unexpected attachments: %+v", attachments)
+	}
+}
+
+func TestMentions(t *testing.T) {
+	doc := content.Parse("Ping @alice and @bob.\n- [ ] ask **@alice** again")
+
+	if got := strings.Join(doc.Mentions(), ","); got != "alice,bob" {
+		t.Errorf("unexpected mentions: %s", got)
+	}
+}
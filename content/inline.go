@@ -0,0 +1,209 @@
+package content
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// InlineKind identifies the type of an [Inline] node.
+type InlineKind int
+
+// Inline kinds.
+const (
+	Text InlineKind = iota
+	Link
+	Image
+	Code
+	Emphasis
+	Strong
+	Strikethrough
+	Mention
+)
+
+// Inline is an inline element of a [Block].
+type Inline struct {
+	Kind InlineKind
+	// Text is the literal text for Text and Code nodes, the link text or image
+	// alt text for Link and Image nodes, and the name without '@' for Mention
+	// nodes. It is empty for container nodes.
+	Text string
+	// URL is the target of Link and Image nodes.
+	URL string
+	// Children holds the content of Emphasis, Strong and Strikethrough nodes.
+	Children []Inline
+}
+
+// ParseInline parses inline markdown: links, images, bare URLs, code spans,
+// emphasis, strong emphasis, strikethrough and @mentions.
+func ParseInline(s string) []Inline {
+	p := inlineParser{src: s}
+	p.parse()
+
+	return p.nodes
+}
+
+type inlineParser struct {
+	src   string
+	pos   int
+	text  strings.Builder
+	nodes []Inline
+}
+
+func (p *inlineParser) parse() {
+	for p.pos < len(p.src) {
+		if node, n, ok := p.match(); ok {
+			p.flushText()
+			p.nodes = append(p.nodes, node)
+			p.pos += n
+
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		p.text.WriteString(p.src[p.pos : p.pos+size])
+		p.pos += size
+	}
+
+	p.flushText()
+}
+
+// match tries to recognize an inline element at the current position and
+// returns it along with the number of bytes it spans.
+func (p *inlineParser) match() (Inline, int, bool) {
+	rest := p.src[p.pos:]
+
+	switch {
+	case rest[0] == '`':
+		return matchCode(rest)
+	case strings.HasPrefix(rest, "!["):
+		node, n, ok := matchLink(rest[1:])
+		node.Kind = Image
+
+		return node, n + 1, ok
+	case rest[0] == '[':
+		return matchLink(rest)
+	case strings.HasPrefix(rest, "http://") || strings.HasPrefix(rest, "https://"):
+		return matchBareURL(rest, p.atWordStart())
+	case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+		return matchDelimited(rest, rest[:2], Strong)
+	case strings.HasPrefix(rest, "~~"):
+		return matchDelimited(rest, "~~", Strikethrough)
+	case rest[0] == '*' || (rest[0] == '_' && p.atWordStart()):
+		return matchDelimited(rest, rest[:1], Emphasis)
+	case rest[0] == '@' && p.atWordStart():
+		return matchMention(rest)
+	}
+
+	return Inline{}, 0, false
+}
+
+// atWordStart reports whether the current position is at the start of the
+// text or follows a character that is not part of a word.
+func (p *inlineParser) atWordStart() bool {
+	if p.pos == 0 {
+		return true
+	}
+
+	r, _ := utf8.DecodeLastRuneInString(p.src[:p.pos])
+
+	return !isWordRune(r)
+}
+
+func (p *inlineParser) flushText() {
+	if p.text.Len() == 0 {
+		return
+	}
+
+	p.nodes = append(p.nodes, Inline{Kind: Text, Text: p.text.String()})
+	p.text.Reset()
+}
+
+func matchCode(s string) (Inline, int, bool) {
+	end := strings.IndexByte(s[1:], '`')
+	if end < 0 {
+		return Inline{}, 0, false
+	}
+
+	// The closing backtick sits at end+1; the span includes both backticks.
+	return Inline{Kind: Code, Text: s[1 : end+1]}, 1 + end + 1, true
+}
+
+// matchLink matches "[text](url)" at the start of s.
+func matchLink(s string) (Inline, int, bool) {
+	if s == "" || s[0] != '[' {
+		return Inline{}, 0, false
+	}
+
+	closeText := strings.Index(s, "](")
+	if closeText < 0 || strings.Contains(s[1:closeText], "\n") {
+		return Inline{}, 0, false
+	}
+
+	closeURL := strings.IndexByte(s[closeText+2:], ')')
+	if closeURL < 0 {
+		return Inline{}, 0, false
+	}
+
+	url := strings.TrimSpace(s[closeText+2 : closeText+2+closeURL])
+
+	return Inline{Kind: Link, Text: s[1:closeText], URL: url}, closeText + 2 + closeURL + 1, true
+}
+
+func matchBareURL(s string, atWordStart bool) (Inline, int, bool) {
+	if !atWordStart {
+		return Inline{}, 0, false
+	}
+
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '<' || r == '>'
+	})
+	if end < 0 {
+		end = len(s)
+	}
+
+	// Trailing punctuation usually ends the sentence rather than the URL.
+	url := strings.TrimRight(s[:end], ".,;:!?)")
+
+	return Inline{Kind: Link, Text: url, URL: url}, len(url), true
+}
+
+func matchDelimited(s, delim string, kind InlineKind) (Inline, int, bool) {
+	inner := s[len(delim):]
+	if inner == "" || unicode.IsSpace(rune(inner[0])) {
+		return Inline{}, 0, false
+	}
+
+	end := strings.Index(inner, delim)
+	if end <= 0 {
+		return Inline{}, 0, false
+	}
+
+	n := len(delim) + end + len(delim)
+
+	return Inline{Kind: kind, Children: ParseInline(inner[:end])}, n, true
+}
+
+func matchMention(s string) (Inline, int, bool) {
+	end := 1
+
+	for end < len(s) {
+		r, size := utf8.DecodeRuneInString(s[end:])
+		if !isWordRune(r) && r != '.' && r != '-' {
+			break
+		}
+
+		end += size
+	}
+
+	name := strings.TrimRight(s[1:end], ".-")
+	if name == "" {
+		return Inline{}, 0, false
+	}
+
+	return Inline{Kind: Mention, Text: name}, len(name) + 1, true
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,65 @@
+package content_test
+
+import (
+	"testing"
+
+	"github.com/slavkluev/go-ticktick/content"
+)
+
+func TestParseInline(t *testing.T) {
+	nodes := content.ParseInline("See **bold _and_ more** `code` ~~old~~ [x](http://a) @bob https://b.example/path.")
+
+	kinds := []content.InlineKind{
+		content.Text, content.Strong, content.Text, content.Code, content.Text,
+		content.Strikethrough, content.Text, content.Link, content.Text, content.Mention,
+		content.Text, content.Link, content.Text,
+	}
+
+	if len(nodes) != len(kinds) {
+		t.Fatalf("expected %d nodes, got %d: %+v", len(kinds), len(nodes), nodes)
+	}
+
+	for i, k := range kinds {
+		if nodes[i].Kind != k {
+			t.Errorf("node %d: expected kind %v, got %v (%+v)", i, k, nodes[i].Kind, nodes[i])
+		}
+	}
+
+	strong := nodes[1].Children
+	if len(strong) != 3 || strong[1].Kind != content.Emphasis || strong[1].Children[0].Text != "and" {
+		t.Errorf("unexpected strong children: %+v", strong)
+	}
+
+	if nodes[9].Text != "bob" {
+		t.Errorf("expected mention bob, got %q", nodes[9].Text)
+	}
+
+	if nodes[11].URL != "https://b.example/path" || nodes[12].Text != "." {
+		t.Errorf("expected trailing period outside URL, got %+v %+v", nodes[11], nodes[12])
+	}
+}
+
+func TestParseInlineLiterals(t *testing.T) {
+	tests := []string{
+		"mail me at me@example.com",
+		"snake_case_name",
+		"unclosed [link",
+		"2 * 3 = 6",
+		"a lone ` backtick",
+	}
+
+	for _, in := range tests {
+		nodes := content.ParseInline(in)
+		if len(nodes) != 1 || nodes[0].Kind != content.Text || nodes[0].Text != in {
+			t.Errorf("ParseInline(%q) = %+v, want single text node", in, nodes)
+		}
+	}
+}
+
+func TestParseInlineImage(t *testing.T) {
+	nodes := content.ParseInline("![alt text](img.png)")
+
+	if len(nodes) != 1 || nodes[0].Kind != content.Image || nodes[0].Text != "alt text" || nodes[0].URL != "img.png" {
+		t.Errorf("unexpected nodes: %+v", nodes)
+	}
+}
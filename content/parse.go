@@ -0,0 +1,205 @@
+// Package content parses the markdown-flavored text TickTick stores in
+// [ticktick.Task.Content] and [ticktick.Task.Desc].
+//
+// TickTick notes use a small subset of markdown: headings, bullet, numbered
+// and checkbox lists, quotes, fenced code, links, images (which is how
+// attachments are embedded) and inline emphasis. [Parse] turns such text into a
+// [Document] of blocks with inline nodes. Helpers extract links, URLs,
+// @mentions and checkbox lines, convert checkboxes to checklist items and back,
+// and render plain text for notifications.
+package content
+
+import (
+	"regexp"
+	"strings"
+)
+
+// BlockKind identifies the type of a [Block].
+type BlockKind int
+
+// Block kinds.
+const (
+	Paragraph BlockKind = iota
+	Heading
+	ListItem
+	Checkbox
+	Quote
+	CodeBlock
+	Rule
+)
+
+var (
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	checkboxPattern = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s?(.*)$`)
+	bulletPattern   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	orderedPattern  = regexp.MustCompile(`^(\s*)\d+[.)]\s+(.*)$`)
+	rulePattern     = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+)
+
+// Block is a block-level element of a [Document].
+type Block struct {
+	Kind BlockKind
+	// Level is the heading level (1-6) for headings and the nesting depth
+	// (0 for top level) for list items and checkboxes.
+	Level int
+	// Ordered reports whether a list item belongs to a numbered list.
+	Ordered bool
+	// Checked reports whether a checkbox is ticked.
+	Checked bool
+	// Text is the raw markdown of the block without its block markers. For
+	// code blocks it is the verbatim code.
+	Text string
+	// Inline holds the parsed inline content of Text. It is empty for code
+	// blocks and rules.
+	Inline []Inline
+}
+
+// Document is parsed TickTick note content.
+type Document struct {
+	Blocks []Block
+}
+
+// Parse parses markdown-flavored TickTick content. It never fails: text that
+// is not recognized as markup is kept as paragraphs.
+func Parse(s string) *Document {
+	p := &parser{}
+
+	for line := range strings.Lines(strings.ReplaceAll(s, "\r\n", "\n")) {
+		p.line(strings.TrimSuffix(line, "\n"))
+	}
+
+	p.flushParagraph()
+
+	if p.inCode {
+		p.doc.Blocks = append(p.doc.Blocks, Block{Kind: CodeBlock, Text: strings.Join(p.code, "\n")})
+	}
+
+	return &p.doc
+}
+
+// Checkboxes returns the document's checkbox blocks in order.
+func (d *Document) Checkboxes() []Block {
+	var boxes []Block
+
+	for _, b := range d.Blocks {
+		if b.Kind == Checkbox {
+			boxes = append(boxes, b)
+		}
+	}
+
+	return boxes
+}
+
+type parser struct {
+	doc       Document
+	paragraph []string
+	inCode    bool
+	code      []string
+}
+
+func (p *parser) line(line string) {
+	if p.inCode {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			p.doc.Blocks = append(p.doc.Blocks, Block{Kind: CodeBlock, Text: strings.Join(p.code, "\n")})
+			p.inCode, p.code = false, nil
+
+			return
+		}
+
+		p.code = append(p.code, line)
+
+		return
+	}
+
+	if block, ok := parseBlockLine(line); ok {
+		p.flushParagraph()
+
+		if block.Kind == CodeBlock {
+			p.inCode = true
+
+			return
+		}
+
+		p.doc.Blocks = append(p.doc.Blocks, block)
+
+		return
+	}
+
+	if strings.TrimSpace(line) == "" {
+		p.flushParagraph()
+
+		return
+	}
+
+	p.paragraph = append(p.paragraph, strings.TrimSpace(line))
+}
+
+func (p *parser) flushParagraph() {
+	if len(p.paragraph) == 0 {
+		return
+	}
+
+	text := strings.Join(p.paragraph, "\n")
+	p.doc.Blocks = append(p.doc.Blocks, Block{Kind: Paragraph, Text: text, Inline: ParseInline(text)})
+	p.paragraph = nil
+}
+
+// parseBlockLine recognizes a line that forms a block on its own. A returned
+// CodeBlock only marks the opening fence.
+func parseBlockLine(line string) (Block, bool) {
+	trimmed := strings.TrimSpace(line)
+
+	switch {
+	case strings.HasPrefix(trimmed, "```"):
+		return Block{Kind: CodeBlock}, true
+	case rulePattern.MatchString(line):
+		return Block{Kind: Rule}, true
+	}
+
+	if m := headingPattern.FindStringSubmatch(trimmed); m != nil {
+		return textBlock(Block{Kind: Heading, Level: len(m[1])}, m[2]), true
+	}
+
+	if m := checkboxPattern.FindStringSubmatch(line); m != nil {
+		return textBlock(Block{Kind: Checkbox, Level: depth(m[1]), Checked: m[2] != " "}, m[3]), true
+	}
+
+	if m := bulletPattern.FindStringSubmatch(line); m != nil {
+		return textBlock(Block{Kind: ListItem, Level: depth(m[1])}, m[2]), true
+	}
+
+	if m := orderedPattern.FindStringSubmatch(line); m != nil {
+		return textBlock(Block{Kind: ListItem, Level: depth(m[1]), Ordered: true}, m[2]), true
+	}
+
+	if rest, ok := strings.CutPrefix(trimmed, ">"); ok {
+		return textBlock(Block{Kind: Quote}, strings.TrimSpace(rest)), true
+	}
+
+	return Block{}, false
+}
+
+func textBlock(b Block, text string) Block {
+	b.Text = strings.TrimSpace(text)
+	b.Inline = ParseInline(b.Text)
+
+	return b
+}
+
+// depth converts list indentation to a nesting depth. Two spaces or one tab
+// make one level.
+func depth(indent string) int {
+	const spacesPerLevel = 2
+
+	n := 0
+
+	for _, c := range indent {
+		if c == '\t' {
+			n += spacesPerLevel
+		} else {
+			n++
+		}
+	}
+
+	return n / spacesPerLevel
+}
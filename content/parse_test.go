@@ -0,0 +1,89 @@
+package content_test
+
+import (
+	"testing"
+
+	"github.com/slavkluev/go-ticktick/content"
+)
+
+const note = "# Weekly plan\n" +
+	"\n" +
+	"Review the [design doc](https://example.com/doc) with @alice.\n" +
+	"Second line of the paragraph.\n" +
+	"\n" +
+	"- [ ] Write tests\n" +
+	"  - [x] Parser\n" +
+	"- Bullet item\n" +
+	"1. Numbered item\n" +
+	"> Quoted text\n" +
+	"---\n" +
+	"```\n" +
+	"go test ./...\n" +
+	"```\n" +
+	"![screenshot](6565/screenshot.png)\n"
+
+func TestParseBlocks(t *testing.T) {
+	doc := content.Parse(note)
+
+	want := []struct {
+		kind  content.BlockKind
+		level int
+		text  string
+	}{
+		{content.Heading, 1, "Weekly plan"},
+		{
+			content.Paragraph, 0,
+			"Review the [design doc](https://example.com/doc) with @alice.\nSecond line of the paragraph.",
+		},
+		{content.Checkbox, 0, "Write tests"},
+		{content.Checkbox, 1, "Parser"},
+		{content.ListItem, 0, "Bullet item"},
+		{content.ListItem, 0, "Numbered item"},
+		{content.Quote, 0, "Quoted text"},
+		{content.Rule, 0, ""},
+		{content.CodeBlock, 0, "go test ./..."},
+		{content.Paragraph, 0, "![screenshot](6565/screenshot.png)"},
+	}
+
+	if len(doc.Blocks) != len(want) {
+		t.Fatalf("expected %d blocks, got %d: %+v", len(want), len(doc.Blocks), doc.Blocks)
+	}
+
+	for i, w := range want {
+		b := doc.Blocks[i]
+		if b.Kind != w.kind || b.Level != w.level || b.Text != w.text {
+			t.Errorf("block %d: expected %v/%d/%q, got %v/%d/%q",
+				i, w.kind, w.level, w.text, b.Kind, b.Level, b.Text)
+		}
+	}
+
+	if doc.Blocks[2].Checked || !doc.Blocks[3].Checked {
+		t.Error("unexpected checkbox state")
+	}
+
+	if doc.Blocks[4].Ordered || !doc.Blocks[5].Ordered {
+		t.Error("unexpected ordered flags")
+	}
+}
+
+func TestParseUnterminatedCodeBlock(t *testing.T) {
+	doc := content.Parse("```\nline one\r\nline two")
+
+	if len(doc.Blocks) != 1 || doc.Blocks[0].Kind != content.CodeBlock || doc.Blocks[0].Text != "line one\nline two" {
+		t.Errorf("unexpected blocks: %+v", doc.Blocks)
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	if doc := content.Parse(""); len(doc.Blocks) != 0 {
+		t.Errorf("expected no blocks, got %+v", doc.Blocks)
+	}
+}
+
+func TestCheckboxes(t *testing.T) {
+	boxes := content.Parse(note).Checkboxes()
+
+	if len(boxes) != 2 || boxes[0].Text != "Write tests" || boxes[1].Text != "Parser" {
+		t.Errorf("unexpected checkboxes: %+v", boxes)
+	}
+}
@@ -0,0 +1,65 @@
+package content
+
+import "strings"
+
+// PlainText renders content as plain text suitable for notifications: markup
+// is removed, links are shown as "text (url)", checkboxes as "[ ]" or "[x]",
+// and list items as "- " lines.
+func PlainText(s string) string {
+	return Parse(s).PlainText()
+}
+
+// PlainText renders the document as plain text. See the package-level [PlainText].
+func (d *Document) PlainText() string {
+	lines := make([]string, 0, len(d.Blocks))
+
+	for _, b := range d.Blocks {
+		indent := strings.Repeat("  ", b.Level)
+
+		switch b.Kind {
+		case Heading, Paragraph:
+			lines = append(lines, inlineText(b.Inline))
+		case Quote:
+			lines = append(lines, "> "+inlineText(b.Inline))
+		case ListItem:
+			lines = append(lines, indent+"- "+inlineText(b.Inline))
+		case Checkbox:
+			box := "[ ] "
+			if b.Checked {
+				box = "[x] "
+			}
+
+			lines = append(lines, indent+box+inlineText(b.Inline))
+		case CodeBlock:
+			lines = append(lines, b.Text)
+		case Rule:
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func inlineText(nodes []Inline) string {
+	var b strings.Builder
+
+	for _, n := range nodes {
+		switch n.Kind {
+		case Text, Code:
+			b.WriteString(n.Text)
+		case Mention:
+			b.WriteString("@" + n.Text)
+		case Link:
+			b.WriteString(n.Text)
+
+			if n.URL != n.Text && n.URL != "" {
+				b.WriteString(" (" + n.URL + ")")
+			}
+		case Image:
+			b.WriteString(n.Text)
+		case Emphasis, Strong, Strikethrough:
+			b.WriteString(inlineText(n.Children))
+		}
+	}
+
+	return b.String()
+}
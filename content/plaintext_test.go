@@ -0,0 +1,30 @@
+package content_test
+
+import (
+	"testing"
+
+	"github.com/slavkluev/go-ticktick/content"
+)
+
+func TestPlainText(t *testing.T) {
+	expected := "Weekly plan\n" +
+		"Review the design doc (https://example.com/doc) with @alice.\nSecond line of the paragraph.\n" +
+		"[ ] Write tests\n" +
+		"  [x] Parser\n" +
+		"- Bullet item\n" +
+		"- Numbered item\n" +
+		"> Quoted text\n" +
+		"go test ./...\n" +
+		"screenshot"
+
+	if got := content.PlainText(note); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestPlainTextInline(t *testing.T) {
+	got := content.PlainText("**Ship** _it_ ~~now~~ `v2` https://example.com")
+	if got != "Ship it now v2 https://example.com" {
+		t.Errorf("unexpected plain text: %q", got)
+	}
+}
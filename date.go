@@ -0,0 +1,173 @@
+package ticktick
+
+import (
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Date is a calendar date without a time of day or time zone. It is used for
+// all-day tasks, whose dates must not move when viewed from another time zone.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the calendar date of t in t's own location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// ParseDate parses a date in the "2006-01-02" format.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("ticktick: cannot parse date %q: %w", s, err)
+	}
+
+	return DateOf(t), nil
+}
+
+// IsZero reports whether d is the zero Date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// String returns d in the "2006-01-02" format, or an empty string for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return d.In(time.UTC).Format(dateLayout)
+}
+
+// In returns midnight at the start of d in loc. A nil loc is treated as UTC.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, locationOrUTC(loc))
+}
+
+// AddDays returns the date n days after d. Negative n moves backwards.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// Before reports whether d is before other.
+func (d Date) Before(other Date) bool {
+	return d.In(time.UTC).Before(other.In(time.UTC))
+}
+
+// After reports whether d is after other.
+func (d Date) After(other Date) bool {
+	return d.In(time.UTC).After(other.In(time.UTC))
+}
+
+// MarshalText encodes d in the "2006-01-02" format.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a date in the "2006-01-02" format. An empty value yields the zero Date.
+func (d *Date) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*d = Date{}
+
+		return nil
+	}
+
+	parsed, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+// AllDayTime returns the timestamp the TickTick apps send for an all-day date:
+// midnight at the start of d in loc. A nil loc is treated as UTC.
+func AllDayTime(d Date, loc *time.Location) *Time {
+	return NewTime(d.In(loc))
+}
+
+// DueDay returns the calendar date of the task's due date in the task's time zone.
+// It returns the zero Date if the task has no due date.
+func (t *Task) DueDay() Date {
+	return dayIn(t.DueDate, t.TimeZone)
+}
+
+// StartDay returns the calendar date of the task's start date in the task's time zone.
+// It returns the zero Date if the task has no start date.
+func (t *Task) StartDay() Date {
+	return dayIn(t.StartDate, t.TimeZone)
+}
+
+// StartDay returns the calendar date of the subtask's start date in the subtask's time zone.
+// It returns the zero Date if the subtask has no start date.
+func (i *ChecklistItem) StartDay() Date {
+	return dayIn(i.StartDate, i.TimeZone)
+}
+
+// SetAllDay makes the request describe an all-day task on d: the start and due
+// dates are set to midnight of d in loc, IsAllDay to true and TimeZone to loc's name.
+// A nil loc is treated as UTC. TimeZone is left unset for [time.Local] and other
+// locations without an IANA name, since the API would reject them.
+func (r *CreateTaskRequest) SetAllDay(d Date, loc *time.Location) {
+	loc = locationOrUTC(loc)
+	r.StartDate = AllDayTime(d, loc)
+	r.DueDate = AllDayTime(d, loc)
+	r.IsAllDay = Bool(true)
+	r.TimeZone = timeZoneOf(r.StartDate)
+}
+
+// SetAllDay makes the request describe an all-day task on d: the start and due
+// dates are set to midnight of d in loc, IsAllDay to true and TimeZone to loc's name.
+// A nil loc is treated as UTC. TimeZone is left unset for [time.Local] and other
+// locations without an IANA name, since the API would reject them.
+func (r *UpdateTaskRequest) SetAllDay(d Date, loc *time.Location) {
+	loc = locationOrUTC(loc)
+	r.StartDate = AllDayTime(d, loc)
+	r.DueDate = AllDayTime(d, loc)
+	r.IsAllDay = Bool(true)
+	r.TimeZone = timeZoneOf(r.StartDate)
+}
+
+// SetAllDay makes the subtask start on the all-day date d: the start date is set to
+// midnight of d in loc, IsAllDay to true and TimeZone to loc's name.
+// A nil loc is treated as UTC. TimeZone is left unset for [time.Local] and other
+// locations without an IANA name, since the API would reject them.
+func (r *CreateChecklistItemRequest) SetAllDay(d Date, loc *time.Location) {
+	loc = locationOrUTC(loc)
+	r.StartDate = AllDayTime(d, loc)
+	r.IsAllDay = Bool(true)
+	r.TimeZone = timeZoneOf(r.StartDate)
+}
+
+// dayIn returns the calendar date of t in the named IANA time zone. If the zone
+// is empty or unknown, t's own location is used.
+func dayIn(t Time, timeZone string) Date {
+	if t.IsZero() {
+		return Date{}
+	}
+
+	if timeZone != "" {
+		if loc, err := time.LoadLocation(timeZone); err == nil {
+			return DateOf(t.In(loc))
+		}
+	}
+
+	return DateOf(t.Time)
+}
+
+func locationOrUTC(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.UTC
+	}
+
+	return loc
+}
@@ -0,0 +1,199 @@
+package ticktick_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+func TestParseDate(t *testing.T) {
+	d, err := ticktick.ParseDate("2024-02-29")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if d != (ticktick.Date{Year: 2024, Month: time.February, Day: 29}) {
+		t.Errorf("unexpected date: %+v", d)
+	}
+
+	if d.String() != "2024-02-29" {
+		t.Errorf("expected 2024-02-29, got %s", d.String())
+	}
+
+	if _, err := ticktick.ParseDate("2024-02-30"); err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
+
+func TestDateArithmetic(t *testing.T) {
+	d := ticktick.Date{Year: 2024, Month: time.December, Day: 31}
+
+	next := d.AddDays(1)
+	if next != (ticktick.Date{Year: 2025, Month: time.January, Day: 1}) {
+		t.Errorf("unexpected next day: %+v", next)
+	}
+
+	if !d.Before(next) || !next.After(d) {
+		t.Error("expected d before next")
+	}
+
+	if !(ticktick.Date{}).IsZero() || d.IsZero() {
+		t.Error("unexpected IsZero result")
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	type wrapper struct {
+		Day ticktick.Date `json:"day"`
+	}
+
+	data, err := json.Marshal(wrapper{Day: ticktick.Date{Year: 2024, Month: time.March, Day: 5}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(data) != `{"day":"2024-03-05"}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	var w wrapper
+
+	if err := json.Unmarshal(data, &w); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if w.Day.String() != "2024-03-05" {
+		t.Errorf("unexpected day: %s", w.Day)
+	}
+
+	if err := json.Unmarshal([]byte(`{"day":""}`), &w); err != nil || !w.Day.IsZero() {
+		t.Errorf("expected zero date, got %+v (err %v)", w.Day, err)
+	}
+}
+
+func TestTaskDueDayAllDay(t *testing.T) {
+	// The TickTick apps store an all-day date as midnight in the task's time zone,
+	// so Jan 15 in Shanghai is Jan 14 16:00 UTC.
+	var task ticktick.Task
+
+	err := json.Unmarshal([]byte(`{
+		"isAllDay": true,
+		"timeZone": "Asia/Shanghai",
+		"startDate": "2024-01-14T16:00:00.000+0000",
+		"dueDate": "2024-01-14T16:00:00.000+0000"
+	}`), &task)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := ticktick.Date{Year: 2024, Month: time.January, Day: 15}
+
+	if task.DueDay() != want {
+		t.Errorf("expected due day %v, got %v", want, task.DueDay())
+	}
+
+	if task.StartDay() != want {
+		t.Errorf("expected start day %v, got %v", want, task.StartDay())
+	}
+}
+
+func TestTaskDueDayWithoutTimeZone(t *testing.T) {
+	task := ticktick.Task{DueDate: ticktick.Time{Time: time.Date(2024, 1, 14, 23, 0, 0, 0, time.UTC)}}
+
+	if task.DueDay() != (ticktick.Date{Year: 2024, Month: time.January, Day: 14}) {
+		t.Errorf("unexpected due day: %v", task.DueDay())
+	}
+
+	if !task.StartDay().IsZero() {
+		t.Errorf("expected zero start day, got %v", task.StartDay())
+	}
+}
+
+func TestChecklistItemStartDay(t *testing.T) {
+	item := ticktick.ChecklistItem{
+		StartDate: ticktick.Time{Time: time.Date(2024, 1, 15, 5, 0, 0, 0, time.UTC)},
+		TimeZone:  "America/New_York",
+	}
+
+	if item.StartDay() != (ticktick.Date{Year: 2024, Month: time.January, Day: 15}) {
+		t.Errorf("unexpected start day: %v", item.StartDay())
+	}
+}
+
+func TestCreateTaskRequestSetAllDay(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	var req ticktick.CreateTaskRequest
+
+	req.SetAllDay(ticktick.Date{Year: 2024, Month: time.January, Day: 15}, loc)
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded["dueDate"] != "2024-01-15T00:00:00+0800" || decoded["startDate"] != "2024-01-15T00:00:00+0800" {
+		t.Errorf("unexpected dates: %v / %v", decoded["startDate"], decoded["dueDate"])
+	}
+
+	if decoded["isAllDay"] != true || decoded["timeZone"] != "Asia/Shanghai" {
+		t.Errorf("unexpected isAllDay/timeZone: %v / %v", decoded["isAllDay"], decoded["timeZone"])
+	}
+}
+
+func TestUpdateRequestsSetAllDay(t *testing.T) {
+	d := ticktick.Date{Year: 2024, Month: time.June, Day: 1}
+
+	var req ticktick.UpdateTaskRequest
+
+	req.SetAllDay(d, nil)
+
+	if req.DueDate == nil || !req.DueDate.Equal(d.In(time.UTC)) || *req.TimeZone != "UTC" {
+		t.Errorf("unexpected update request: %+v", req)
+	}
+
+	var item ticktick.CreateChecklistItemRequest
+
+	item.SetAllDay(d, time.UTC)
+
+	if item.StartDate == nil || item.IsAllDay == nil || !*item.IsAllDay {
+		t.Errorf("unexpected item request: %+v", item)
+	}
+}
+
+func TestSetAllDayUnnamedLocation(t *testing.T) {
+	d := ticktick.Date{Year: 2024, Month: time.June, Day: 1}
+
+	for _, loc := range []*time.Location{time.Local, time.FixedZone("", 3*60*60)} {
+		var req ticktick.CreateTaskRequest
+
+		req.SetAllDay(d, loc)
+
+		if req.TimeZone != nil {
+			t.Errorf("expected no time zone for %v, got %q", loc, *req.TimeZone)
+		}
+
+		var update ticktick.UpdateTaskRequest
+
+		update.SetAllDay(d, loc)
+
+		var item ticktick.CreateChecklistItemRequest
+
+		item.SetAllDay(d, loc)
+
+		if update.TimeZone != nil || item.TimeZone != nil {
+			t.Errorf("expected no time zone for %v, got %v / %v", loc, update.TimeZone, item.TimeZone)
+		}
+	}
+}
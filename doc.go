@@ -70,5 +70,9 @@ response body. Use [errors.As] to inspect them:
 			log.Printf("HTTP %d: %s", apiErr.StatusCode, apiErr.Body)
 		}
 	}
+
+Create and update requests are validated before they are sent. Invalid
+requests fail with a [*ValidationError] listing every invalid field; use
+[WithValidation] to disable the check.
 */
 package ticktick
@@ -0,0 +1,159 @@
+package ticktick
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Folder is a project group: the projects that share a [Project.GroupID].
+type Folder struct {
+	// ID is the GroupID shared by the folder's projects.
+	ID string `json:"id"`
+	// Name is the folder's display name. The Open API does not expose folder
+	// names, so it is empty unless set by the caller.
+	Name     string    `json:"name,omitempty"`
+	Projects []Project `json:"projects"`
+}
+
+// ProjectTree arranges projects the way the TickTick sidebar does: open projects
+// grouped into folders, open projects outside any folder, and closed (archived)
+// projects. Every list is ordered by SortOrder.
+type ProjectTree struct {
+	// Folders are ordered by the lowest SortOrder among their projects.
+	Folders   []Folder  `json:"folders"`
+	Ungrouped []Project `json:"ungrouped"`
+	Closed    []Project `json:"closed"`
+}
+
+// NewProjectTree builds a folder tree from the result of [Client.GetProjects].
+func NewProjectTree(projects []Project) *ProjectTree {
+	tree := &ProjectTree{}
+	index := make(map[string]int)
+
+	for _, p := range sortedProjects(projects) {
+		switch {
+		case p.Closed:
+			tree.Closed = append(tree.Closed, p)
+		case p.GroupID == "":
+			tree.Ungrouped = append(tree.Ungrouped, p)
+		default:
+			i, ok := index[p.GroupID]
+			if !ok {
+				i = len(tree.Folders)
+				index[p.GroupID] = i
+				tree.Folders = append(tree.Folders, Folder{ID: p.GroupID})
+			}
+
+			tree.Folders[i].Projects = append(tree.Folders[i].Projects, p)
+		}
+	}
+
+	return tree
+}
+
+// GetProjectTree returns all projects for the authenticated user arranged into folders.
+func (c *Client) GetProjectTree(ctx context.Context) (*ProjectTree, error) {
+	projects, err := c.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewProjectTree(projects), nil
+}
+
+// Folder returns the folder with the given ID, or nil if there is none.
+func (t *ProjectTree) Folder(id string) *Folder {
+	for i := range t.Folders {
+		if t.Folders[i].ID == id {
+			return &t.Folders[i]
+		}
+	}
+
+	return nil
+}
+
+// Render writes the tree as indented text suitable for terminal output.
+// Folders and ungrouped projects are interleaved by SortOrder, a folder taking
+// the place of its first project, and closed projects come last:
+//
+//	Personal
+//	Work
+//	├── Backlog
+//	└── Sprint
+//	Closed
+//	└── Old project
+func (t *ProjectTree) Render(w io.Writer) error {
+	var b strings.Builder
+
+	folders, ungrouped := t.Folders, t.Ungrouped
+
+	for len(folders) > 0 || len(ungrouped) > 0 {
+		if len(ungrouped) > 0 && (len(folders) == 0 || ungrouped[0].SortOrder < folderSortOrder(folders[0])) {
+			b.WriteString(ungrouped[0].Name + "\n")
+			ungrouped = ungrouped[1:]
+
+			continue
+		}
+
+		f := folders[0]
+		folders = folders[1:]
+
+		b.WriteString(cmp.Or(f.Name, f.ID) + "\n")
+		writeBranches(&b, f.Projects)
+	}
+
+	if len(t.Closed) > 0 {
+		b.WriteString("Closed\n")
+		writeBranches(&b, t.Closed)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("ticktick: render project tree: %w", err)
+	}
+
+	return nil
+}
+
+// String returns the tree as rendered by [ProjectTree.Render].
+func (t *ProjectTree) String() string {
+	var b strings.Builder
+
+	_ = t.Render(&b)
+
+	return b.String()
+}
+
+// folderSortOrder returns the lowest SortOrder among the folder's projects.
+func folderSortOrder(f Folder) int64 {
+	if len(f.Projects) == 0 {
+		return 0
+	}
+
+	return slices.MinFunc(f.Projects, func(a, b Project) int {
+		return cmp.Compare(a.SortOrder, b.SortOrder)
+	}).SortOrder
+}
+
+func writeBranches(b *strings.Builder, projects []Project) {
+	for i, p := range projects {
+		branch := "├── "
+		if i == len(projects)-1 {
+			branch = "└── "
+		}
+
+		b.WriteString(branch + p.Name + "\n")
+	}
+}
+
+func sortedProjects(projects []Project) []Project {
+	projects = slices.Clone(projects)
+	slices.SortStableFunc(projects, func(a, b Project) int {
+		return cmp.Compare(a.SortOrder, b.SortOrder)
+	})
+
+	return projects
+}
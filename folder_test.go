@@ -0,0 +1,126 @@
+package ticktick_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+func testProjects() []ticktick.Project {
+	return []ticktick.Project{
+		{ID: "p1", Name: "Sprint", GroupID: "work", SortOrder: 30},
+		{ID: "p2", Name: "Backlog", GroupID: "work", SortOrder: 20},
+		{ID: "p3", Name: "Personal", SortOrder: 10},
+		{ID: "p4", Name: "Reading", GroupID: "home", SortOrder: 40},
+		{ID: "p5", Name: "Old project", GroupID: "work", SortOrder: 5, Closed: true},
+	}
+}
+
+func TestNewProjectTree(t *testing.T) {
+	tree := ticktick.NewProjectTree(testProjects())
+
+	if len(tree.Folders) != 2 || tree.Folders[0].ID != "work" || tree.Folders[1].ID != "home" {
+		t.Fatalf("unexpected folders: %+v", tree.Folders)
+	}
+
+	work := tree.Folders[0].Projects
+	if len(work) != 2 || work[0].ID != "p2" || work[1].ID != "p1" {
+		t.Errorf("unexpected work projects: %+v", work)
+	}
+
+	if len(tree.Ungrouped) != 1 || tree.Ungrouped[0].ID != "p3" {
+		t.Errorf("unexpected ungrouped projects: %+v", tree.Ungrouped)
+	}
+
+	if len(tree.Closed) != 1 || tree.Closed[0].ID != "p5" {
+		t.Errorf("unexpected closed projects: %+v", tree.Closed)
+	}
+
+	if f := tree.Folder("home"); f == nil || len(f.Projects) != 1 {
+		t.Errorf("unexpected home folder: %+v", f)
+	}
+
+	if tree.Folder("missing") != nil {
+		t.Error("expected nil for missing folder")
+	}
+}
+
+func TestProjectTreeRender(t *testing.T) {
+	tree := ticktick.NewProjectTree(testProjects())
+	tree.Folder("work").Name = "Work"
+
+	expected := "Personal\n" +
+		"Work\n" +
+		"├── Backlog\n" +
+		"└── Sprint\n" +
+		"home\n" +
+		"└── Reading\n" +
+		"Closed\n" +
+		"└── Old project\n"
+
+	if got := tree.String(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestProjectTreeJSON(t *testing.T) {
+	data, err := json.Marshal(ticktick.NewProjectTree(testProjects()[:1]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded struct {
+		Folders []struct {
+			ID       string `json:"id"`
+			Projects []struct {
+				ID string `json:"id"`
+			} `json:"projects"`
+		} `json:"folders"`
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(decoded.Folders) != 1 || decoded.Folders[0].Projects[0].ID != "p1" {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestGetProjectTree(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open/v1/project" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		json.NewEncoder(w).Encode(testProjects())
+	})
+	defer server.Close()
+
+	tree, err := client.GetProjectTree(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tree.Folders) != 2 {
+		t.Errorf("expected 2 folders, got %d", len(tree.Folders))
+	}
+}
+
+func TestGetProjectTreeError(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	defer server.Close()
+
+	_, err := client.GetProjectTree(context.Background())
+
+	var apiErr *ticktick.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *ticktick.Error, got %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+// Package history keeps a record of completed TickTick tasks.
+//
+// [ticktick.Client.CompletedTasks] lists completions directly, but not every
+// account's API offers that endpoint, and completed tasks disappear from
+// [ticktick.Client.GetProjectData]. A [Tracker] fills the gap: each call to
+// [Tracker.Observe] compares the open tasks with those seen the previous time,
+// looks up the ones that disappeared and records those that were completed in
+// a [Store]. Run Observe periodically, e.g. from cron, and
+// [Tracker.CompletedTasks] falls back to the recorded completions whenever
+// the endpoint is unavailable.
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// State is the data a [Tracker] persists between observations.
+type State struct {
+	// Open holds the open tasks seen by the last observation, keyed by task ID.
+	Open map[string]ticktick.Task `json:"open"`
+	// Completed holds the completions recorded so far, in the order they
+	// were observed.
+	Completed []ticktick.Task `json:"completed"`
+}
+
+// Tracker records task completions by comparing successive observations of
+// the account's open tasks.
+type Tracker struct {
+	client *ticktick.Client
+	store  Store
+	now    func() time.Time
+}
+
+// NewTracker creates a Tracker that observes tasks through client and keeps
+// its state in store.
+func NewTracker(client *ticktick.Client, store Store) *Tracker {
+	return &Tracker{client: client, store: store, now: time.Now}
+}
+
+// Observe fetches the open tasks of every open project and of the Inbox, and
+// records the tasks that were open at the previous observation but are now
+// completed, as well as completed tasks listed among the open ones. Each task
+// ID is recorded once. Tasks that were deleted are forgotten. A completed task
+// without a CompletedTime gets the observation time. The first call only
+// records the open tasks.
+func (t *Tracker) Observe(ctx context.Context) error {
+	state, err := t.store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("history: load state: %w", err)
+	}
+
+	tasks, err := t.openTasks(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := t.now()
+	open := make(map[string]ticktick.Task, len(tasks))
+
+	recorded := make(map[string]bool, len(state.Completed))
+	for _, task := range state.Completed {
+		recorded[task.ID] = true
+	}
+
+	record := func(task ticktick.Task) {
+		if !recorded[task.ID] {
+			recorded[task.ID] = true
+			state.Completed = append(state.Completed, completed(task, now))
+		}
+	}
+
+	for _, task := range tasks {
+		if task.Status == ticktick.TaskStatusCompleted {
+			record(task)
+
+			continue
+		}
+
+		open[task.ID] = task
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(state.Open)) {
+		if _, ok := open[id]; ok {
+			continue
+		}
+
+		prev := state.Open[id]
+
+		task, err := t.client.GetTask(ctx, prev.ProjectID, id)
+		if ticktick.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("history: get task %s: %w", id, err)
+		}
+
+		if task.Status == ticktick.TaskStatusCompleted {
+			record(*task)
+		}
+	}
+
+	state.Open = open
+
+	if err := t.store.Save(ctx, state); err != nil {
+		return fmt.Errorf("history: save state: %w", err)
+	}
+
+	return nil
+}
+
+// CompletedTasks lists completed tasks using [ticktick.Client.CompletedTasks].
+// If the endpoint is unavailable, it returns the completions recorded by
+// [Tracker.Observe] instead; see [Tracker.Recorded].
+func (t *Tracker) CompletedTasks(ctx context.Context, req *ticktick.CompletedTasksRequest) ([]ticktick.Task, error) {
+	tasks, err := t.client.CompletedTasks(ctx, req)
+	if err == nil || !endpointUnavailable(err) {
+		return tasks, err
+	}
+
+	return t.Recorded(ctx, req)
+}
+
+// Recorded returns the recorded completions matching req, ordered by
+// CompletedTime. StartDate and EndDate are inclusive bounds. A nil request
+// returns every recorded completion.
+func (t *Tracker) Recorded(ctx context.Context, req *ticktick.CompletedTasksRequest) ([]ticktick.Task, error) {
+	state, err := t.store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("history: load state: %w", err)
+	}
+
+	if req == nil {
+		req = &ticktick.CompletedTasksRequest{}
+	}
+
+	var tasks []ticktick.Task
+
+	for _, task := range state.Completed {
+		if matches(task, req) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	slices.SortStableFunc(tasks, func(a, b ticktick.Task) int {
+		return a.CompletedTime.Compare(b.CompletedTime.Time)
+	})
+
+	return tasks, nil
+}
+
+func (t *Tracker) openTasks(ctx context.Context) ([]ticktick.Task, error) {
+	tasks, err := t.client.AllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("history: list tasks: %w", err)
+	}
+
+	inbox, err := t.client.Inbox(ctx)
+	if errors.Is(err, ticktick.ErrInboxUnsupported) {
+		return tasks, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("history: list inbox: %w", err)
+	}
+
+	return append(tasks, inbox.Tasks...), nil
+}
+
+func completed(task ticktick.Task, now time.Time) ticktick.Task {
+	if task.CompletedTime.IsZero() {
+		task.CompletedTime = ticktick.Time{Time: now}
+	}
+
+	return task
+}
+
+func matches(task ticktick.Task, req *ticktick.CompletedTasksRequest) bool {
+	if len(req.ProjectIDs) > 0 && !slices.Contains(req.ProjectIDs, task.ProjectID) {
+		return false
+	}
+
+	if req.StartDate != nil && !req.StartDate.IsZero() && task.CompletedTime.Before(req.StartDate.Time) {
+		return false
+	}
+
+	if req.EndDate != nil && !req.EndDate.IsZero() && task.CompletedTime.After(req.EndDate.Time) {
+		return false
+	}
+
+	return true
+}
+
+// endpointUnavailable reports whether err means the API does not offer the
+// requested endpoint, as opposed to a transient or authorization failure.
+func endpointUnavailable(err error) bool {
+	return isStatus(err, http.StatusNotFound) || isStatus(err, http.StatusMethodNotAllowed) ||
+		isStatus(err, http.StatusNotImplemented)
+}
+
+func isStatus(err error, code int) bool {
+	var apiErr *ticktick.Error
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == code
+}
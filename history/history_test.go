@@ -0,0 +1,225 @@
+package history_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/history"
+)
+
+// fakeAPI serves one project whose open tasks can be changed between
+// observations. Tasks in completed are returned by GetTask; for others it
+// answers like the real API, with 200 and an empty body, or with missing if
+// set.
+type fakeAPI struct {
+	open      []ticktick.Task
+	completed map[string]ticktick.Task
+	endpoint  bool
+	missing   int
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/open/v1/project":
+		json.NewEncoder(w).Encode([]ticktick.Project{{ID: "p1"}})
+	case "/open/v1/project/p1/data":
+		json.NewEncoder(w).Encode(ticktick.ProjectData{Tasks: f.open})
+	case "/open/v1/project/inbox/data":
+		w.WriteHeader(http.StatusNotFound)
+	case "/open/v1/task/completed":
+		if !f.endpoint {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		json.NewEncoder(w).Encode([]ticktick.Task{{ID: "from-api"}})
+	default:
+		for id, task := range f.completed {
+			if r.URL.Path == "/open/v1/project/p1/task/"+id {
+				json.NewEncoder(w).Encode(task)
+
+				return
+			}
+		}
+
+		if f.missing != 0 {
+			w.WriteHeader(f.missing)
+		}
+	}
+}
+
+func newTracker(t *testing.T, api *fakeAPI) *history.Tracker {
+	t.Helper()
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	client := ticktick.NewClient("token", ticktick.WithBaseURL(server.URL))
+
+	return history.NewTracker(client, &history.MemoryStore{})
+}
+
+func TestTrackerObserve(t *testing.T) {
+	doneAt := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	api := &fakeAPI{
+		open: []ticktick.Task{
+			{ID: "t1", ProjectID: "p1"},
+			{ID: "t2", ProjectID: "p1"},
+			{ID: "t3", ProjectID: "p1"},
+		},
+		completed: map[string]ticktick.Task{
+			"t2": {
+				ID:            "t2",
+				ProjectID:     "p1",
+				Status:        ticktick.TaskStatusCompleted,
+				CompletedTime: ticktick.Time{Time: doneAt},
+			},
+		},
+	}
+	tracker := newTracker(t, api)
+	ctx := context.Background()
+
+	if err := tracker.Observe(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// t2 was completed and t3 deleted.
+	api.open = api.open[:1]
+
+	if err := tracker.Observe(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tasks, err := tracker.CompletedTasks(ctx, &ticktick.CompletedTasksRequest{
+		ProjectIDs: []string{"p1"},
+		StartDate:  ticktick.NewTime(doneAt.Add(-time.Hour)),
+		EndDate:    ticktick.NewTime(doneAt),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tasks) != 1 || tasks[0].ID != "t2" {
+		t.Fatalf("expected [t2], got %+v", tasks)
+	}
+
+	if !tasks[0].CompletedTime.Equal(doneAt) {
+		t.Errorf("expected completed time %v, got %v", doneAt, tasks[0].CompletedTime)
+	}
+}
+
+func TestTrackerObserveDeletedTask(t *testing.T) {
+	for _, missing := range []int{0, http.StatusNotFound} {
+		api := &fakeAPI{open: []ticktick.Task{{ID: "t1", ProjectID: "p1"}}, missing: missing}
+		tracker := newTracker(t, api)
+		ctx := context.Background()
+
+		if err := tracker.Observe(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		api.open = nil
+
+		// The deleted task is forgotten, so later observations succeed too.
+		for range 2 {
+			if err := tracker.Observe(ctx); err != nil {
+				t.Fatalf("status %d: unexpected error: %v", missing, err)
+			}
+		}
+
+		if tasks, _ := tracker.Recorded(ctx, nil); len(tasks) != 0 {
+			t.Errorf("status %d: expected no completions, got %+v", missing, tasks)
+		}
+	}
+}
+
+func TestTrackerRecordedFilters(t *testing.T) {
+	doneAt := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	api := &fakeAPI{
+		open: []ticktick.Task{{ID: "t1", ProjectID: "p1"}},
+		completed: map[string]ticktick.Task{
+			"t1": {
+				ID:            "t1",
+				ProjectID:     "p1",
+				Status:        ticktick.TaskStatusCompleted,
+				CompletedTime: ticktick.Time{Time: doneAt},
+			},
+		},
+	}
+	tracker := newTracker(t, api)
+	ctx := context.Background()
+
+	tracker.Observe(ctx)
+
+	api.open = nil
+
+	tracker.Observe(ctx)
+
+	tests := []struct {
+		name string
+		req  *ticktick.CompletedTasksRequest
+		want int
+	}{
+		{"nil request", nil, 1},
+		{"other project", &ticktick.CompletedTasksRequest{ProjectIDs: []string{"p2"}}, 0},
+		{"before range", &ticktick.CompletedTasksRequest{StartDate: ticktick.NewTime(doneAt.Add(time.Second))}, 0},
+		{"after range", &ticktick.CompletedTasksRequest{EndDate: ticktick.NewTime(doneAt.Add(-time.Second))}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := tracker.Recorded(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(tasks) != tt.want {
+				t.Errorf("expected %d tasks, got %d", tt.want, len(tasks))
+			}
+		})
+	}
+}
+
+func TestTrackerCompletedTasksFallback(t *testing.T) {
+	api := &fakeAPI{open: []ticktick.Task{
+		{ID: "t1", ProjectID: "p1", Status: ticktick.TaskStatusCompleted},
+		{ID: "t2", ProjectID: "p1"},
+	}}
+	tracker := newTracker(t, api)
+	ctx := context.Background()
+
+	// The completed task is listed by every observation but recorded once.
+	for range 2 {
+		if err := tracker.Observe(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tasks, err := tracker.CompletedTasks(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tasks) != 1 || tasks[0].ID != "t1" {
+		t.Errorf("expected the recorded [t1], got %+v", tasks)
+	}
+}
+
+func TestTrackerCompletedTasksUsesEndpoint(t *testing.T) {
+	tracker := newTracker(t, &fakeAPI{endpoint: true})
+
+	tasks, err := tracker.CompletedTasks(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tasks) != 1 || tasks[0].ID != "from-api" {
+		t.Errorf("expected the endpoint's tasks, got %+v", tasks)
+	}
+}
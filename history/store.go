@@ -0,0 +1,105 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// Store persists the [State] of a [Tracker]. Load returns an empty State when
+// nothing has been saved yet.
+type Store interface {
+	Load(ctx context.Context) (*State, error)
+	Save(ctx context.Context, state *State) error
+}
+
+// MemoryStore keeps the state in memory. The zero value is ready to use.
+type MemoryStore struct {
+	mu    sync.Mutex
+	state State
+}
+
+// Load returns a copy of the stored state.
+func (s *MemoryStore) Load(_ context.Context) (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &State{Open: maps.Clone(s.state.Open), Completed: slices.Clone(s.state.Completed)}, nil
+}
+
+// Save replaces the stored state with a copy of state.
+func (s *MemoryStore) Save(_ context.Context, state *State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = State{Open: maps.Clone(state.Open), Completed: slices.Clone(state.Completed)}
+
+	return nil
+}
+
+// FileStore keeps the state in a JSON file.
+type FileStore struct {
+	path string
+}
+
+// NewFileStore creates a FileStore backed by the file at path. The file is
+// created on the first Save.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load reads the state from the file.
+func (s *FileStore) Load(_ context.Context) (*State, error) {
+	data, err := os.ReadFile(s.path) //nolint:gosec // G304: the path is chosen by the caller
+	if errors.Is(err, fs.ErrNotExist) {
+		return &State{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var state State
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+// Save writes the state to the file. It writes a temporary file first and
+// renames it, so an interrupted Save leaves the previous state intact.
+func (s *FileStore) Save(_ context.Context, state *State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,55 @@
+package history_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/history"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store := history.NewFileStore(filepath.Join(t.TempDir(), "history.json"))
+
+	state, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(state.Open) != 0 || len(state.Completed) != 0 {
+		t.Errorf("expected empty state, got %+v", state)
+	}
+
+	state.Open = map[string]ticktick.Task{"t1": {ID: "t1", Title: "Open"}}
+	state.Completed = []ticktick.Task{{ID: "t2", Status: ticktick.TaskStatusCompleted}}
+
+	if err := store.Save(ctx, state); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if loaded.Open["t1"].Title != "Open" || len(loaded.Completed) != 1 || loaded.Completed[0].ID != "t2" {
+		t.Errorf("unexpected state: %+v", loaded)
+	}
+}
+
+func TestMemoryStoreCopies(t *testing.T) {
+	ctx := context.Background()
+	store := &history.MemoryStore{}
+
+	state := &history.State{Completed: []ticktick.Task{{ID: "t1"}}}
+	store.Save(ctx, state)
+
+	state.Completed[0].ID = "changed"
+
+	loaded, _ := store.Load(ctx)
+	if loaded.Completed[0].ID != "t1" {
+		t.Errorf("expected stored copy to be unaffected, got %s", loaded.Completed[0].ID)
+	}
+}
@@ -0,0 +1,262 @@
+package ical
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// ProdID identifies this library as the producer of exported calendars.
+const ProdID = "-//slavkluev//go-ticktick//EN"
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405Z"
+)
+
+// iCalendar priorities run from 1 (highest) to 9 (lowest); 0 means undefined.
+const (
+	icalPriorityHigh   = 1
+	icalPriorityMedium = 5
+	icalPriorityLow    = 9
+)
+
+// Export writes a VCALENDAR with the tasks of the given projects to w.
+func Export(w io.Writer, projects ...*ticktick.ProjectData) error {
+	return Calendar(projects...).Encode(w)
+}
+
+// Calendar returns a VCALENDAR with a VTODO for every task of the given
+// projects and for every checklist item. When exporting a single project, its
+// name becomes the calendar name.
+func Calendar(projects ...*ticktick.ProjectData) *Component {
+	return calendar(stampAt(time.Now()), projects...)
+}
+
+// stampFunc returns the DTSTAMP of a task without a modification time.
+type stampFunc func(task *ticktick.Task) time.Time
+
+// stampAt returns a stampFunc stamping every task with t.
+func stampAt(t time.Time) stampFunc {
+	return func(*ticktick.Task) time.Time { return t }
+}
+
+// calendar is [Calendar] with the DTSTAMP of tasks without a modification time
+// taken from unmodified.
+func calendar(unmodified stampFunc, projects ...*ticktick.ProjectData) *Component {
+	cal := &Component{Name: "VCALENDAR"}
+	cal.Add("VERSION", "2.0")
+	cal.Add("PRODID", ProdID)
+	cal.Add("CALSCALE", "GREGORIAN")
+
+	if len(projects) == 1 && projects[0].Project.Name != "" {
+		cal.Add("X-WR-CALNAME", EscapeText(projects[0].Project.Name))
+	}
+
+	for _, data := range projects {
+		for i := range data.Tasks {
+			cal.Components = append(cal.Components, todos(&data.Tasks[i], unmodified)...)
+		}
+	}
+
+	return cal
+}
+
+// Todos converts a task to a VTODO followed by one VTODO per checklist item.
+//
+// The task's ID becomes the UID and checklist items use "<task ID>-<item ID>".
+// Priority is mapped to the iCalendar scale (high 1, medium 5, low 9),
+// RepeatFlag to RRULE and each reminder to a display VALARM. Repeat rules
+// iCalendar cannot express (ERULE) are kept in X-TICKTICK-REPEAT.
+func Todos(task *ticktick.Task) []*Component {
+	return todos(task, stampAt(time.Now()))
+}
+
+func todos(task *ticktick.Task, unmodified stampFunc) []*Component {
+	todo := &Component{Name: "VTODO"}
+	todo.Add("UID", task.ID)
+	todo.Add("DTSTAMP", stamp(task, unmodified))
+
+	if modified := task.ModifiedTime(); !modified.IsZero() {
+		todo.Add("LAST-MODIFIED", modified.UTC().Format(dateTimeFormat))
+	}
+
+	todo.Add("SUMMARY", EscapeText(task.Title))
+
+	if desc := description(task); desc != "" {
+		todo.Add("DESCRIPTION", EscapeText(desc))
+	}
+
+	addDates(todo, task)
+
+	if p := icalPriority(task.Priority); p != 0 {
+		todo.Add("PRIORITY", strconv.Itoa(p))
+	}
+
+	addStatus(todo, task.Status == ticktick.TaskStatusCompleted, task.CompletedTime)
+
+	if tags := task.EffectiveTags(); len(tags) > 0 {
+		escaped := make([]string, len(tags))
+		for i, tag := range tags {
+			escaped[i] = EscapeText(tag)
+		}
+
+		todo.Add("CATEGORIES", strings.Join(escaped, ","))
+	}
+
+	if task.ParentID != "" {
+		todo.Add("RELATED-TO", task.ParentID, Param{"RELTYPE", "PARENT"})
+	}
+
+	addRepeat(todo, task.RepeatFlag)
+	addAlarms(todo, task)
+
+	list := []*Component{todo}
+
+	for i := range task.Items {
+		list = append(list, itemTodo(task, &task.Items[i], unmodified))
+	}
+
+	return list
+}
+
+func itemTodo(task *ticktick.Task, item *ticktick.ChecklistItem, unmodified stampFunc) *Component {
+	todo := &Component{Name: "VTODO"}
+	todo.Add("UID", task.ID+"-"+item.ID)
+	todo.Add("DTSTAMP", stamp(task, unmodified))
+	todo.Add("SUMMARY", EscapeText(item.Title))
+
+	if !item.StartDate.IsZero() {
+		if item.IsAllDay {
+			todo.Add("DTSTART", dateValue(item.StartDay()), Param{"VALUE", "DATE"})
+		} else {
+			todo.Add("DTSTART", item.StartDate.UTC().Format(dateTimeFormat))
+		}
+	}
+
+	addStatus(todo, item.Status == ticktick.ChecklistStatusCompleted, item.CompletedTime)
+	todo.Add("RELATED-TO", task.ID, Param{"RELTYPE", "PARENT"})
+
+	return todo
+}
+
+// stamp returns the DTSTAMP of a task: its modification time, or the time
+// unmodified returns if that is unknown.
+func stamp(task *ticktick.Task, unmodified stampFunc) string {
+	t := task.ModifiedTime().Time
+	if t.IsZero() {
+		t = unmodified(task)
+	}
+
+	return t.UTC().Format(dateTimeFormat)
+}
+
+func description(task *ticktick.Task) string {
+	var parts []string
+
+	for _, s := range []string{task.Content, task.Desc} {
+		if s = strings.TrimSpace(s); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// addDates adds DTSTART and DUE. A start date equal to the due date is
+// omitted, since iCalendar requires DUE to be later than DTSTART.
+func addDates(todo *Component, task *ticktick.Task) {
+	if task.IsAllDay {
+		start, due := task.StartDay(), task.DueDay()
+
+		if !start.IsZero() && start != due {
+			todo.Add("DTSTART", dateValue(start), Param{"VALUE", "DATE"})
+		}
+
+		if !due.IsZero() {
+			todo.Add("DUE", dateValue(due), Param{"VALUE", "DATE"})
+		}
+
+		return
+	}
+
+	if !task.StartDate.IsZero() && !task.StartDate.Equal(task.DueDate.Time) {
+		todo.Add("DTSTART", task.StartDate.UTC().Format(dateTimeFormat))
+	}
+
+	if !task.DueDate.IsZero() {
+		todo.Add("DUE", task.DueDate.UTC().Format(dateTimeFormat))
+	}
+}
+
+func addStatus(todo *Component, done bool, completed ticktick.Time) {
+	if !done {
+		todo.Add("STATUS", "NEEDS-ACTION")
+
+		return
+	}
+
+	todo.Add("STATUS", "COMPLETED")
+	todo.Add("PERCENT-COMPLETE", "100")
+
+	if !completed.IsZero() {
+		todo.Add("COMPLETED", completed.UTC().Format(dateTimeFormat))
+	}
+}
+
+func addRepeat(todo *Component, flag string) {
+	if flag == "" {
+		return
+	}
+
+	if rule, ok := strings.CutPrefix(flag, "RRULE:"); ok {
+		todo.Add("RRULE", rule)
+
+		return
+	}
+
+	todo.Add("X-TICKTICK-REPEAT", EscapeText(flag))
+}
+
+// addAlarms adds a display VALARM per reminder. Reminder triggers are relative
+// to the start of the task; tasks with only a due date get alarms relative to
+// DUE.
+func addAlarms(todo *Component, task *ticktick.Task) {
+	var params []Param
+	if todo.Get("DTSTART") == nil {
+		params = []Param{{"RELATED", "END"}}
+	}
+
+	for _, reminder := range task.Reminders {
+		trigger, ok := strings.CutPrefix(reminder, "TRIGGER:")
+		if !ok {
+			continue
+		}
+
+		alarm := &Component{Name: "VALARM"}
+		alarm.Add("ACTION", "DISPLAY")
+		alarm.Add("DESCRIPTION", EscapeText(task.Title))
+		alarm.Add("TRIGGER", trigger, params...)
+		todo.Components = append(todo.Components, alarm)
+	}
+}
+
+func icalPriority(p int) int {
+	switch p {
+	case ticktick.PriorityHigh:
+		return icalPriorityHigh
+	case ticktick.PriorityMedium:
+		return icalPriorityMedium
+	case ticktick.PriorityLow:
+		return icalPriorityLow
+	default:
+		return 0
+	}
+}
+
+func dateValue(d ticktick.Date) string {
+	return d.In(time.UTC).Format(dateFormat)
+}
@@ -0,0 +1,124 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/ical"
+)
+
+func TestExport(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	data := &ticktick.ProjectData{
+		Project: ticktick.Project{ID: "p1", Name: "Work, Inc"},
+		Tasks: []ticktick.Task{
+			{
+				ID:         "t1",
+				Title:      "Ship release",
+				Content:    "Line one\nLine two",
+				StartDate:  ticktick.Time{Time: time.Date(2024, 1, 15, 9, 0, 0, 0, berlin)},
+				DueDate:    ticktick.Time{Time: time.Date(2024, 1, 15, 17, 0, 0, 0, berlin)},
+				TimeZone:   "Europe/Berlin",
+				Priority:   ticktick.PriorityHigh,
+				RepeatFlag: "RRULE:FREQ=WEEKLY;INTERVAL=1",
+				Reminders:  []string{"TRIGGER:-PT15M"},
+				Tags:       []string{"release"},
+				Items: []ticktick.ChecklistItem{
+					{ID: "i1", Title: "Tag", Status: ticktick.ChecklistStatusCompleted},
+				},
+			},
+			{
+				ID:            "t2",
+				Title:         "Holiday",
+				IsAllDay:      true,
+				StartDate:     ticktick.Time{Time: time.Date(2024, 2, 1, 0, 0, 0, 0, berlin)},
+				DueDate:       ticktick.Time{Time: time.Date(2024, 2, 1, 0, 0, 0, 0, berlin)},
+				TimeZone:      "Europe/Berlin",
+				Status:        ticktick.TaskStatusCompleted,
+				CompletedTime: ticktick.Time{Time: time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC)},
+				RepeatFlag:    "ERULE:NAME=CUSTOM;BYDATE=20240201",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Export(&buf, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Work\\, Inc\r\n",
+		"UID:t1\r\n",
+		"SUMMARY:Ship release\r\n",
+		"DESCRIPTION:Line one\\nLine two\r\n",
+		"DTSTART:20240115T080000Z\r\n",
+		"DUE:20240115T160000Z\r\n",
+		"PRIORITY:1\r\n",
+		"STATUS:NEEDS-ACTION\r\n",
+		"CATEGORIES:release\r\n",
+		"RRULE:FREQ=WEEKLY;INTERVAL=1\r\n",
+		"BEGIN:VALARM\r\nACTION:DISPLAY\r\nDESCRIPTION:Ship release\r\nTRIGGER:-PT15M\r\nEND:VALARM\r\n",
+		"UID:t1-i1\r\n",
+		"RELATED-TO;RELTYPE=PARENT:t1\r\n",
+		"UID:t2\r\n",
+		"DUE;VALUE=DATE:20240201\r\n",
+		"STATUS:COMPLETED\r\nPERCENT-COMPLETE:100\r\nCOMPLETED:20240201T120000Z\r\n",
+		"X-TICKTICK-REPEAT:ERULE:NAME=CUSTOM\\;BYDATE=20240201\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q\n%s", want, out)
+		}
+	}
+
+	if strings.Contains(out, "DTSTART;VALUE=DATE") {
+		t.Error("expected DTSTART to be omitted when it equals the due date")
+	}
+}
+
+func TestTodosAlarmRelatedToDue(t *testing.T) {
+	task := &ticktick.Task{
+		ID:        "t1",
+		Title:     "Pay rent",
+		DueDate:   ticktick.Time{Time: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+		Reminders: []string{"TRIGGER:PT0S"},
+	}
+
+	todos := ical.Todos(task)
+	if len(todos) != 1 || len(todos[0].Components) != 1 {
+		t.Fatalf("expected one VTODO with one VALARM, got %+v", todos)
+	}
+
+	trigger := todos[0].Components[0].Get("TRIGGER")
+	if trigger == nil || trigger.Param("RELATED") != "END" || trigger.Value != "PT0S" {
+		t.Errorf("unexpected trigger: %+v", trigger)
+	}
+}
+
+func TestPriorityMapping(t *testing.T) {
+	tests := []struct {
+		priority int
+		want     string
+	}{
+		{ticktick.PriorityHigh, "1"},
+		{ticktick.PriorityMedium, "5"},
+		{ticktick.PriorityLow, "9"},
+	}
+
+	for _, tt := range tests {
+		todo := ical.Todos(&ticktick.Task{ID: "t", Priority: tt.priority})[0]
+		if p := todo.Get("PRIORITY"); p == nil || p.Value != tt.want {
+			t.Errorf("priority %d: expected %s, got %+v", tt.priority, tt.want, p)
+		}
+	}
+
+	if todo := ical.Todos(&ticktick.Task{ID: "t"})[0]; todo.Get("PRIORITY") != nil {
+		t.Error("expected no PRIORITY for PriorityNone")
+	}
+}
@@ -0,0 +1,313 @@
+package ical
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// DefaultFeedTTL is how long a [Feed] reuses the tasks it fetched.
+const DefaultFeedTTL = 5 * time.Minute
+
+// Feed is an [http.Handler] that serves a read-only calendar of the tasks
+// with a due date, for subscribing from calendar applications.
+//
+// The tasks of every open project and of the Inbox are fetched on the first
+// request and reused for the feed's TTL. If a refresh fails, the previous
+// tasks are served until a refresh succeeds. Responses carry an ETag, so
+// polling clients that send If-None-Match get 304 Not Modified when nothing
+// changed. Tasks without a modification time are stamped with the time they
+// were first fetched, so the calendar only changes when the tasks do.
+//
+// Query parameters narrow the feed; each may be repeated or hold a
+// comma-separated list:
+//
+//   - project: project IDs to include
+//   - priority: priorities to include, as numbers (0, 1, 3, 5) or names
+//     (none, low, medium, high)
+type Feed struct {
+	client *ticktick.Client
+	ttl    time.Duration
+	name   string
+	now    func() time.Time
+
+	mu       sync.Mutex
+	projects []*ticktick.ProjectData
+	fetched  time.Time
+	// firstSeen maps the IDs of the fetched tasks to the time they were
+	// first fetched.
+	firstSeen map[string]time.Time
+}
+
+// FeedOption configures a [Feed].
+type FeedOption func(*Feed)
+
+// WithTTL sets how long fetched tasks are reused. The default is
+// [DefaultFeedTTL].
+func WithTTL(ttl time.Duration) FeedOption {
+	return func(f *Feed) {
+		f.ttl = ttl
+	}
+}
+
+// WithCalendarName sets the calendar name shown by calendar applications.
+func WithCalendarName(name string) FeedOption {
+	return func(f *Feed) {
+		f.name = name
+	}
+}
+
+// NewFeed creates a Feed serving tasks fetched through client.
+func NewFeed(client *ticktick.Client, opts ...FeedOption) *Feed {
+	f := &Feed{
+		client: client,
+		ttl:    DefaultFeedTTL,
+		name:   "TickTick",
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// ServeHTTP serves the calendar.
+func (f *Feed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	filter, err := parseFeedFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	projects, firstSeen, err := f.load(r.Context())
+	if err != nil {
+		http.Error(w, "cannot load tasks", http.StatusBadGateway)
+
+		return
+	}
+
+	cal := calendar(func(task *ticktick.Task) time.Time { return firstSeen[task.ID] }, filter.apply(projects)...)
+	cal.Set("X-WR-CALNAME", EscapeText(f.name))
+
+	var buf bytes.Buffer
+	if err := cal.Encode(&buf); err != nil {
+		http.Error(w, "cannot encode calendar", http.StatusInternalServerError)
+
+		return
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(f.ttl.Seconds())))
+	w.Header().Set("Etag", etag)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	_, _ = w.Write(buf.Bytes())
+}
+
+// Invalidate discards the fetched tasks, so the next request fetches them again.
+func (f *Feed) Invalidate() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.projects = nil
+	f.fetched = time.Time{}
+}
+
+// load returns the cached projects and when each of their tasks was first
+// fetched, refreshing them once the TTL has passed. Concurrent requests wait
+// for a single refresh. The returned map is not modified afterwards.
+func (f *Feed) load(ctx context.Context) ([]*ticktick.ProjectData, map[string]time.Time, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.projects != nil && f.now().Sub(f.fetched) < f.ttl {
+		return f.projects, f.firstSeen, nil
+	}
+
+	projects, err := f.fetch(ctx)
+	if err != nil {
+		if f.projects != nil {
+			return f.projects, f.firstSeen, nil
+		}
+
+		return nil, nil, err
+	}
+
+	f.projects, f.fetched = projects, f.now()
+
+	// Tasks that are gone are dropped, so the map does not grow forever.
+	firstSeen := make(map[string]time.Time)
+
+	for _, data := range projects {
+		for _, task := range data.Tasks {
+			firstSeen[task.ID] = f.fetched
+
+			if seen, ok := f.firstSeen[task.ID]; ok {
+				firstSeen[task.ID] = seen
+			}
+		}
+	}
+
+	f.firstSeen = firstSeen
+
+	return projects, firstSeen, nil
+}
+
+func (f *Feed) fetch(ctx context.Context) ([]*ticktick.ProjectData, error) {
+	list, err := f.client.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []*ticktick.ProjectData
+
+	for _, p := range list {
+		if p.Closed {
+			continue
+		}
+
+		data, err := f.client.GetProjectData(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if data.Project.ID == "" {
+			data.Project = p
+		}
+
+		projects = append(projects, data)
+	}
+
+	inbox, err := f.client.Inbox(ctx)
+	if err != nil && !errors.Is(err, ticktick.ErrInboxUnsupported) {
+		return nil, err
+	}
+
+	if inbox != nil {
+		projects = append(projects, inbox)
+	}
+
+	return projects, nil
+}
+
+// feedFilter holds the query parameters of a feed request. Empty lists match
+// everything.
+type feedFilter struct {
+	projectIDs []string
+	priorities []int
+}
+
+func parseFeedFilter(r *http.Request) (feedFilter, error) {
+	query := r.URL.Query()
+
+	filter := feedFilter{projectIDs: listParam(query["project"])}
+
+	for _, s := range listParam(query["priority"]) {
+		p, err := parsePriority(s)
+		if err != nil {
+			return feedFilter{}, err
+		}
+
+		filter.priorities = append(filter.priorities, p)
+	}
+
+	return filter, nil
+}
+
+// apply returns copies of the projects holding only the matching tasks that
+// have a due date.
+func (f feedFilter) apply(projects []*ticktick.ProjectData) []*ticktick.ProjectData {
+	var out []*ticktick.ProjectData
+
+	for _, data := range projects {
+		if len(f.projectIDs) > 0 && !slices.Contains(f.projectIDs, data.Project.ID) {
+			continue
+		}
+
+		filtered := &ticktick.ProjectData{Project: data.Project, Columns: data.Columns}
+
+		for _, task := range data.Tasks {
+			if task.DueDate.IsZero() {
+				continue
+			}
+
+			if len(f.priorities) > 0 && !slices.Contains(f.priorities, task.Priority) {
+				continue
+			}
+
+			filtered.Tasks = append(filtered.Tasks, task)
+		}
+
+		out = append(out, filtered)
+	}
+
+	return out
+}
+
+func listParam(values []string) []string {
+	var list []string
+
+	for _, v := range values {
+		for item := range strings.SplitSeq(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+
+	return list
+}
+
+func parsePriority(s string) (int, error) {
+	switch strings.ToLower(s) {
+	case "none":
+		return ticktick.PriorityNone, nil
+	case "low":
+		return ticktick.PriorityLow, nil
+	case "medium":
+		return ticktick.PriorityMedium, nil
+	case "high":
+		return ticktick.PriorityHigh, nil
+	}
+
+	p, err := strconv.Atoi(s)
+	if err != nil || (p != ticktick.PriorityNone && p != ticktick.PriorityLow &&
+		p != ticktick.PriorityMedium && p != ticktick.PriorityHigh) {
+		return 0, fmt.Errorf("invalid priority %q", s)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,174 @@
+package ical_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/ical"
+)
+
+// feedAPI serves two projects and reports 404 for the Inbox.
+func feedAPI(t *testing.T, calls *atomic.Int32) *ticktick.Client {
+	t.Helper()
+
+	due := ticktick.Time{Time: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		switch r.URL.Path {
+		case "/open/v1/project":
+			json.NewEncoder(w).Encode([]ticktick.Project{{ID: "p1"}, {ID: "p2"}, {ID: "p3", Closed: true}})
+		case "/open/v1/project/p1/data":
+			json.NewEncoder(w).Encode(ticktick.ProjectData{Tasks: []ticktick.Task{
+				{ID: "high", ProjectID: "p1", Title: "High", DueDate: due, Priority: ticktick.PriorityHigh},
+				{ID: "low", ProjectID: "p1", Title: "Low", DueDate: due, Priority: ticktick.PriorityLow},
+				{ID: "undated", ProjectID: "p1", Title: "Undated"},
+			}})
+		case "/open/v1/project/p2/data":
+			json.NewEncoder(w).Encode(ticktick.ProjectData{Tasks: []ticktick.Task{
+				{ID: "other", ProjectID: "p2", Title: "Other", DueDate: due},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return ticktick.NewClient("token", ticktick.WithBaseURL(server.URL))
+}
+
+func get(t *testing.T, h http.Handler, target string, header http.Header) *http.Response {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec.Result()
+}
+
+func TestFeed(t *testing.T) {
+	var calls atomic.Int32
+
+	feed := ical.NewFeed(feedAPI(t, &calls), ical.WithCalendarName("Team"))
+
+	resp := get(t, feed, "/tasks.ics", nil)
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/calendar; charset=utf-8" {
+		t.Errorf("unexpected content type: %s", ct)
+	}
+
+	out := string(body)
+
+	for _, want := range []string{"X-WR-CALNAME:Team\r\n", "UID:high\r\n", "UID:low\r\n", "UID:other\r\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected feed to contain %q", want)
+		}
+	}
+
+	if strings.Contains(out, "UID:undated") {
+		t.Error("expected tasks without a due date to be left out")
+	}
+
+	// GetProjects, two projects and the Inbox.
+	if calls.Load() != 4 {
+		t.Errorf("expected 4 API calls, got %d", calls.Load())
+	}
+
+	get(t, feed, "/tasks.ics?project=p2", nil)
+
+	if calls.Load() != 4 {
+		t.Errorf("expected the second request to be served from cache, got %d calls", calls.Load())
+	}
+}
+
+func TestFeedFilters(t *testing.T) {
+	var calls atomic.Int32
+
+	feed := ical.NewFeed(feedAPI(t, &calls))
+
+	body, _ := io.ReadAll(get(t, feed, "/?project=p1&priority=high,3", nil).Body)
+	out := string(body)
+
+	if !strings.Contains(out, "UID:high\r\n") || strings.Contains(out, "UID:low") ||
+		strings.Contains(out, "UID:other") {
+		t.Errorf("unexpected filtered feed:\n%s", out)
+	}
+
+	if resp := get(t, feed, "/?priority=urgent", nil); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid priority, got %d", resp.StatusCode)
+	}
+}
+
+func TestFeedConditionalGet(t *testing.T) {
+	var calls atomic.Int32
+
+	// Every request fetches the tasks again.
+	feed := ical.NewFeed(feedAPI(t, &calls), ical.WithTTL(time.Nanosecond))
+
+	etag := get(t, feed, "/", nil).Header.Get("Etag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+
+	// Wait for the next second, so that a DTSTAMP taken from the clock or the
+	// fetch time would change the calendar.
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+
+	resp := get(t, feed, "/", http.Header{"If-None-Match": {etag}})
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("expected 304, got %d", resp.StatusCode)
+	}
+}
+
+func TestFeedInvalidate(t *testing.T) {
+	var calls atomic.Int32
+
+	feed := ical.NewFeed(feedAPI(t, &calls), ical.WithTTL(time.Hour))
+
+	get(t, feed, "/", nil)
+	feed.Invalidate()
+	get(t, feed, "/", nil)
+
+	if calls.Load() != 8 {
+		t.Errorf("expected a refetch after Invalidate, got %d calls", calls.Load())
+	}
+}
+
+func TestFeedErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	feed := ical.NewFeed(ticktick.NewClient("token", ticktick.WithBaseURL(server.URL)))
+
+	if resp := get(t, feed, "/", nil); resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected 502, got %d", resp.StatusCode)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	feed.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,152 @@
+// Package ical converts TickTick tasks to and from iCalendar (RFC 5545) data,
+// so that tasks can be shared with calendar applications.
+//
+// Tasks become VTODO components. Dates of all-day tasks are written as DATE
+// values and other dates as UTC DATE-TIME values. Checklist items become
+// VTODOs of their own, related to their task by RELATED-TO. [Export] writes a
+// VCALENDAR for one or more projects; [Component] gives access to the
+// generated components for further customization before encoding.
+//
+// In the other direction, [Parse] reads any iCalendar data and [Import]
+// creates tasks from its VTODO and VEVENT components.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Component is an iCalendar component such as VCALENDAR, VTODO or VALARM.
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []*Component
+}
+
+// Property is a content line of a component. Value is kept in its encoded
+// form; use [EscapeText] for TEXT values.
+type Property struct {
+	Name   string
+	Params []Param
+	Value  string
+}
+
+// Param is a property parameter such as VALUE=DATE or TZID=Europe/Berlin.
+type Param struct {
+	Name  string
+	Value string
+}
+
+// Add appends a property to the component.
+func (c *Component) Add(name, value string, params ...Param) {
+	c.Properties = append(c.Properties, Property{Name: name, Params: params, Value: value})
+}
+
+// Set replaces the value and parameters of the first property with the given
+// name, or adds the property if the component has none.
+func (c *Component) Set(name, value string, params ...Param) {
+	if p := c.Get(name); p != nil {
+		p.Value, p.Params = value, params
+
+		return
+	}
+
+	c.Add(name, value, params...)
+}
+
+// Get returns the first property with the given name, or nil.
+func (c *Component) Get(name string) *Property {
+	for i := range c.Properties {
+		if strings.EqualFold(c.Properties[i].Name, name) {
+			return &c.Properties[i]
+		}
+	}
+
+	return nil
+}
+
+// Param returns the value of the named parameter, or "" if it is absent.
+func (p *Property) Param(name string) string {
+	for _, param := range p.Params {
+		if strings.EqualFold(param.Name, name) {
+			return param.Value
+		}
+	}
+
+	return ""
+}
+
+// Encode writes the component in iCalendar format, with CRLF line endings
+// and lines folded at 75 octets.
+func (c *Component) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	c.encode(bw)
+
+	return bw.Flush()
+}
+
+func (c *Component) encode(w *bufio.Writer) {
+	writeLine(w, "BEGIN:"+c.Name)
+
+	for _, p := range c.Properties {
+		writeLine(w, p.String())
+	}
+
+	for _, child := range c.Components {
+		child.encode(w)
+	}
+
+	writeLine(w, "END:"+c.Name)
+}
+
+// String returns the unfolded content line of the property.
+func (p *Property) String() string {
+	var b strings.Builder
+
+	b.WriteString(p.Name)
+
+	for _, param := range p.Params {
+		b.WriteString(";" + param.Name + "=")
+
+		if strings.ContainsAny(param.Value, ";:,") {
+			b.WriteString(`"` + param.Value + `"`)
+		} else {
+			b.WriteString(param.Value)
+		}
+	}
+
+	b.WriteString(":" + p.Value)
+
+	return b.String()
+}
+
+// EscapeText escapes a TEXT value: backslashes, semicolons, commas and
+// newlines.
+func EscapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// writeLine writes a content line, folding it so that no line exceeds 75
+// octets. Continuation lines start with a space. Folds never split a UTF-8
+// sequence.
+func writeLine(w *bufio.Writer, line string) {
+	const maxOctets = 75
+
+	limit := maxOctets
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		_, _ = w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts towards its length.
+		limit = maxOctets - 1
+	}
+
+	_, _ = w.WriteString(line + "\r\n")
+}
@@ -0,0 +1,76 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick/ical"
+)
+
+func TestComponentEncode(t *testing.T) {
+	c := &ical.Component{Name: "VTODO"}
+	c.Add("UID", "t1")
+	c.Add("DUE", "20240115", ical.Param{Name: "VALUE", Value: "DATE"})
+	c.Add("X-NAME", "x", ical.Param{Name: "X-PARAM", Value: "a:b"})
+	c.Components = append(c.Components, &ical.Component{Name: "VALARM"})
+
+	var buf bytes.Buffer
+	if err := c.Encode(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "BEGIN:VTODO\r\nUID:t1\r\nDUE;VALUE=DATE:20240115\r\nX-NAME;X-PARAM=\"a:b\":x\r\n" +
+		"BEGIN:VALARM\r\nEND:VALARM\r\nEND:VTODO\r\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestComponentEncodeFolding(t *testing.T) {
+	c := &ical.Component{Name: "VTODO"}
+	c.Add("SUMMARY", strings.Repeat("é", 100))
+
+	var buf bytes.Buffer
+	c.Encode(&buf)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	for i, line := range lines {
+		if len(line) > 75 {
+			t.Errorf("line %d has %d octets", i, len(line))
+		}
+	}
+
+	unfolded := strings.ReplaceAll(buf.String(), "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+strings.Repeat("é", 100)+"\r\n") {
+		t.Errorf("folding corrupted the value: %q", buf.String())
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	got := ical.EscapeText("a,b;c\\d\ne")
+	if got != `a\,b\;c\\d\ne` {
+		t.Errorf("unexpected escape: %s", got)
+	}
+}
+
+func TestGetAndParam(t *testing.T) {
+	c := &ical.Component{Name: "VTODO"}
+	c.Add("DUE", "20240115", ical.Param{Name: "VALUE", Value: "DATE"})
+
+	p := c.Get("due")
+	if p == nil || p.Param("value") != "DATE" {
+		t.Fatalf("unexpected property: %+v", p)
+	}
+
+	if c.Get("DTSTART") != nil {
+		t.Error("expected nil for a missing property")
+	}
+
+	c.Set("DUE", "20240116T090000Z")
+	c.Set("SUMMARY", "Added")
+
+	if p := c.Get("DUE"); len(c.Properties) != 2 || p.Value != "20240116T090000Z" || len(p.Params) != 0 {
+		t.Errorf("unexpected properties after Set: %+v", c.Properties)
+	}
+}
@@ -0,0 +1,489 @@
+package ical
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// untitled is the title given to components without a SUMMARY.
+const untitled = "Untitled"
+
+// Entry is a task read from a calendar.
+type Entry struct {
+	// UID is the UID of the component the task was read from.
+	UID     string
+	Request *ticktick.CreateTaskRequest
+	// Completed reports whether the component has STATUS:COMPLETED. Such
+	// tasks are completed right after they are created.
+	Completed bool
+}
+
+// ImportOptions configures [Import].
+type ImportOptions struct {
+	// ProjectID is the project the tasks are created in. It is required.
+	ProjectID string
+	// Location is used for floating times, all-day dates and TZIDs that are
+	// not IANA time zone names. Nil means UTC.
+	Location *time.Location
+	// DryRun reports the tasks that would be created without sending anything.
+	DryRun bool
+	// Imported maps UIDs to the IDs of the tasks created from them. Components
+	// whose UID is in Imported are skipped, and Import adds each task it
+	// creates. Keep the map between runs, e.g. as a JSON file, to make
+	// repeated imports of the same calendar idempotent. It may be nil.
+	Imported map[string]string
+}
+
+// ImportedTask is a task created, or in a dry run about to be created, by
+// [Import].
+type ImportedTask struct {
+	Entry
+
+	// Task is the created task. It is nil in a dry run.
+	Task *ticktick.Task
+}
+
+// ImportResult reports the outcome of [Import].
+type ImportResult struct {
+	Created []ImportedTask
+	// Skipped lists the UIDs of components that were imported before, either
+	// according to ImportOptions.Imported or because the project already has
+	// a task with that ID.
+	Skipped []string
+}
+
+// Import reads a calendar and creates a task for each VTODO and VEVENT in it;
+// see [Entries] for the mapping. The target project is read first so that
+// calendars exported by this package are not imported back as duplicates. On
+// error, the result lists the tasks created so far.
+func Import(ctx context.Context, client *ticktick.Client, r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	if opts.ProjectID == "" {
+		return nil, errors.New("ical: ImportOptions.ProjectID must not be empty")
+	}
+
+	cal, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := Entries(cal, opts.ProjectID, opts.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := client.GetProjectData(ctx, opts.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("ical: read project %s: %w", opts.ProjectID, err)
+	}
+
+	existing := make(map[string]bool, len(data.Tasks))
+	for _, task := range data.Tasks {
+		existing[task.ID] = true
+	}
+
+	result := &ImportResult{}
+
+	for _, e := range entries {
+		if _, ok := opts.Imported[e.UID]; (ok || existing[e.UID]) && e.UID != "" {
+			result.Skipped = append(result.Skipped, e.UID)
+
+			continue
+		}
+
+		if opts.DryRun {
+			result.Created = append(result.Created, ImportedTask{Entry: e})
+
+			continue
+		}
+
+		task, err := createTask(ctx, client, e)
+		if err != nil {
+			return result, err
+		}
+
+		if opts.Imported != nil && e.UID != "" {
+			opts.Imported[e.UID] = task.ID
+		}
+
+		result.Created = append(result.Created, ImportedTask{Entry: e, Task: task})
+	}
+
+	return result, nil
+}
+
+// Entries converts the VTODO and VEVENT components of cal into task requests
+// for projectID.
+//
+// SUMMARY becomes the title and DESCRIPTION the content. DTSTART and DUE (or
+// DTEND for events, which is exclusive for all-day events) become the start
+// and due dates; DATE values make an all-day task. Times with a TZID keep
+// their time zone. RRULE becomes RepeatFlag, relative VALARM triggers become
+// reminders, PRIORITY is mapped to TickTick's scale (1-4 high, 5 medium, 6-9
+// low) and CATEGORIES to tags. VTODOs related to another VTODO of the
+// calendar by RELATED-TO become its checklist items. Recurrence overrides
+// (components with RECURRENCE-ID) and repeated UIDs are ignored.
+func Entries(cal *Component, projectID string, loc *time.Location) ([]Entry, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	components := taskComponents(cal)
+
+	var (
+		entries []Entry
+		index   = make(map[string]int)
+	)
+
+	for _, c := range components {
+		if isItem(c, components) {
+			continue
+		}
+
+		e, err := entry(c, projectID, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		index[e.UID] = len(entries)
+		entries = append(entries, e)
+	}
+
+	for _, c := range components {
+		if !isItem(c, components) {
+			continue
+		}
+
+		item, err := checklistItem(c, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		i := index[parentUID(c)]
+		entries[i].Request.Items = append(entries[i].Request.Items, item)
+	}
+
+	return entries, nil
+}
+
+func createTask(ctx context.Context, client *ticktick.Client, e Entry) (*ticktick.Task, error) {
+	task, err := client.CreateTask(ctx, e.Request)
+	if err != nil {
+		return nil, fmt.Errorf("ical: create task %s: %w", e.UID, err)
+	}
+
+	if e.Completed {
+		if err := client.CompleteTask(ctx, task.ProjectID, task.ID); err != nil {
+			return nil, fmt.Errorf("ical: complete task %s: %w", e.UID, err)
+		}
+
+		task.Status = ticktick.TaskStatusCompleted
+	}
+
+	return task, nil
+}
+
+// taskComponents returns the VTODOs and VEVENTs of cal, dropping recurrence
+// overrides and repeated UIDs.
+func taskComponents(cal *Component) []*Component {
+	var components []*Component
+
+	seen := make(map[string]bool)
+
+	for _, c := range cal.Components {
+		if (c.Name != "VTODO" && c.Name != "VEVENT") || c.Get("RECURRENCE-ID") != nil {
+			continue
+		}
+
+		if id := uid(c); id != "" {
+			if seen[id] {
+				continue
+			}
+
+			seen[id] = true
+		}
+
+		components = append(components, c)
+	}
+
+	return components
+}
+
+func entry(c *Component, projectID string, loc *time.Location) (Entry, error) {
+	req := &ticktick.CreateTaskRequest{
+		ProjectID: projectID,
+		Title:     text(c, "SUMMARY"),
+	}
+
+	if req.Title == "" {
+		req.Title = untitled
+	}
+
+	if desc := text(c, "DESCRIPTION"); desc != "" {
+		req.Content = ticktick.String(desc)
+	}
+
+	if err := setDates(req, c, loc); err != nil {
+		return Entry{}, fmt.Errorf("ical: %s %s: %w", c.Name, uid(c), err)
+	}
+
+	if p := c.Get("RRULE"); p != nil {
+		req.RepeatFlag = ticktick.String("RRULE:" + p.Value)
+	} else if flag := text(c, "X-TICKTICK-REPEAT"); flag != "" {
+		req.RepeatFlag = ticktick.String(flag)
+	}
+
+	if p := c.Get("PRIORITY"); p != nil {
+		if n, err := strconv.Atoi(p.Value); err == nil && n > 0 {
+			req.Priority = ticktick.Int(tickTickPriority(n))
+		}
+	}
+
+	req.Tags = categories(c)
+	req.Reminders = reminders(c)
+
+	return Entry{UID: uid(c), Request: req, Completed: completed(c)}, nil
+}
+
+func checklistItem(c *Component, loc *time.Location) (ticktick.CreateChecklistItemRequest, error) {
+	item := ticktick.CreateChecklistItemRequest{
+		Title:  text(c, "SUMMARY"),
+		Status: ticktick.Int(ticktick.ChecklistStatusNormal),
+	}
+
+	if item.Title == "" {
+		item.Title = untitled
+	}
+
+	if completed(c) {
+		item.Status = ticktick.Int(ticktick.ChecklistStatusCompleted)
+	}
+
+	if p := c.Get("DTSTART"); p != nil {
+		t, allDay, err := parseTime(p, loc)
+		if err != nil {
+			return item, fmt.Errorf("ical: VTODO %s: %w", uid(c), err)
+		}
+
+		item.StartDate = ticktick.NewTime(t)
+		item.IsAllDay = ticktick.Bool(allDay)
+	}
+
+	return item, nil
+}
+
+// setDates sets the start and due dates. A task is all-day only if all its
+// dates are DATE values.
+func setDates(req *ticktick.CreateTaskRequest, c *Component, loc *time.Location) error {
+	endName := "DUE"
+	if c.Name == "VEVENT" {
+		endName = "DTEND"
+	}
+
+	start, startAllDay, err := optionalTime(c.Get("DTSTART"), loc)
+	if err != nil {
+		return err
+	}
+
+	end, endAllDay, err := optionalTime(c.Get(endName), loc)
+	if err != nil {
+		return err
+	}
+
+	allDay := (start.IsZero() || startAllDay) && (end.IsZero() || endAllDay) && !(start.IsZero() && end.IsZero())
+
+	// The DTEND of an all-day event is the day after the event.
+	if c.Name == "VEVENT" && allDay && !end.IsZero() {
+		end = end.AddDate(0, 0, -1)
+		if end.Before(start) {
+			end = start
+		}
+	}
+
+	if !start.IsZero() {
+		req.StartDate = ticktick.NewTime(start)
+	}
+
+	if !end.IsZero() {
+		req.DueDate = ticktick.NewTime(end)
+	}
+
+	if allDay {
+		req.IsAllDay = ticktick.Bool(true)
+	}
+
+	return nil
+}
+
+func optionalTime(p *Property, loc *time.Location) (time.Time, bool, error) {
+	if p == nil {
+		return time.Time{}, false, nil
+	}
+
+	return parseTime(p, loc)
+}
+
+// parseTime parses a DATE or DATE-TIME value and reports whether it is a
+// DATE. DATE values are returned as midnight in loc.
+func parseTime(p *Property, loc *time.Location) (time.Time, bool, error) {
+	value := p.Value
+
+	if strings.EqualFold(p.Param("VALUE"), "DATE") || len(value) == len(dateFormat) {
+		t, err := time.ParseInLocation(dateFormat, value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s date %q", p.Name, value)
+		}
+
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeFormat, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s time %q", p.Name, value)
+		}
+
+		return t, false, nil
+	}
+
+	if tzid := p.Param("TZID"); tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+
+	t, err := time.ParseInLocation(strings.TrimSuffix(dateTimeFormat, "Z"), value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s time %q", p.Name, value)
+	}
+
+	return t, false, nil
+}
+
+func reminders(c *Component) []string {
+	var triggers []string
+
+	for _, alarm := range c.Components {
+		if alarm.Name != "VALARM" {
+			continue
+		}
+
+		p := alarm.Get("TRIGGER")
+		if p == nil || strings.EqualFold(p.Param("VALUE"), "DATE-TIME") {
+			continue
+		}
+
+		triggers = append(triggers, "TRIGGER:"+p.Value)
+	}
+
+	return triggers
+}
+
+func categories(c *Component) []string {
+	var tags []string
+
+	for _, p := range c.Properties {
+		if p.Name != "CATEGORIES" {
+			continue
+		}
+
+		for _, tag := range splitText(p.Value) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return tags
+}
+
+// splitText splits a list of TEXT values at unescaped commas and unescapes them.
+func splitText(s string) []string {
+	var (
+		values []string
+		start  int
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			values = append(values, UnescapeText(s[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(values, UnescapeText(s[start:]))
+}
+
+func completed(c *Component) bool {
+	p := c.Get("STATUS")
+
+	return (p != nil && strings.EqualFold(p.Value, "COMPLETED")) || c.Get("COMPLETED") != nil
+}
+
+func tickTickPriority(p int) int {
+	switch {
+	case p < icalPriorityMedium:
+		return ticktick.PriorityHigh
+	case p == icalPriorityMedium:
+		return ticktick.PriorityMedium
+	default:
+		return ticktick.PriorityLow
+	}
+}
+
+func text(c *Component, name string) string {
+	if p := c.Get(name); p != nil {
+		return strings.TrimSpace(UnescapeText(p.Value))
+	}
+
+	return ""
+}
+
+func uid(c *Component) string {
+	if p := c.Get("UID"); p != nil {
+		return p.Value
+	}
+
+	return ""
+}
+
+// parentUID returns the UID of the component's parent, or "".
+func parentUID(c *Component) string {
+	for _, p := range c.Properties {
+		if p.Name != "RELATED-TO" {
+			continue
+		}
+
+		if reltype := p.Param("RELTYPE"); reltype == "" || strings.EqualFold(reltype, "PARENT") {
+			return p.Value
+		}
+	}
+
+	return ""
+}
+
+// isItem reports whether c is a VTODO belonging to another component of the
+// calendar that is not itself nested, which makes it a checklist item.
+func isItem(c *Component, components []*Component) bool {
+	parent := parentUID(c)
+	if c.Name != "VTODO" || parent == "" {
+		return false
+	}
+
+	for _, p := range components {
+		if uid(p) == parent {
+			return parentUID(p) == ""
+		}
+	}
+
+	return false
+}
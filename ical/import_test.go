@@ -0,0 +1,275 @@
+package ical_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/ical"
+)
+
+const calendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"UID:todo-1\r\n" +
+	"SUMMARY:Write report\\, draft\r\n" +
+	"DESCRIPTION:First line\\nSecond line\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20240115T090000\r\n" +
+	"DUE;TZID=Europe/Berlin:20240115T170000\r\n" +
+	"RRULE:FREQ=WEEKLY\r\n" +
+	"PRIORITY:2\r\n" +
+	"CATEGORIES:work,q1\\,2024\r\n" +
+	"BEGIN:VALARM\r\nACTION:DISPLAY\r\nTRIGGER:-PT15M\r\nEND:VALARM\r\n" +
+	"BEGIN:VALARM\r\nACTION:DISPLAY\r\nTRIGGER;VALUE=DATE-TIME:20240115T080000Z\r\nEND:VALARM\r\n" +
+	"END:VTODO\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"UID:todo-1-a\r\n" +
+	"SUMMARY:Outline\r\n" +
+	"STATUS:COMPLETED\r\n" +
+	"RELATED-TO:todo-1\r\n" +
+	"END:VTODO\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:event-1\r\n" +
+	"SUMMARY:Conference\r\n" +
+	"DTSTART;VALUE=DATE:20240201\r\n" +
+	"DTEND;VALUE=DATE:20240203\r\n" +
+	"PRIORITY:7\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:event-1\r\n" +
+	"RECURRENCE-ID:20240202\r\n" +
+	"SUMMARY:Override\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"UID:done-1\r\n" +
+	"SUMMARY:Filed taxes\r\n" +
+	"DUE:20240110T120000Z\r\n" +
+	"STATUS:COMPLETED\r\n" +
+	"END:VTODO\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestEntries(t *testing.T) {
+	cal, err := ical.Parse(strings.NewReader(calendar))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := ical.Entries(cal, "proj1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	todo := entries[0].Request
+
+	if todo.Title != "Write report, draft" || *todo.Content != "First line\nSecond line" || todo.ProjectID != "proj1" {
+		t.Errorf("unexpected text fields: %+v", todo)
+	}
+
+	if todo.StartDate.Location().String() != "Europe/Berlin" || todo.StartDate.Hour() != 9 {
+		t.Errorf("expected 09:00 Europe/Berlin, got %v", todo.StartDate)
+	}
+
+	if !todo.DueDate.Equal(time.Date(2024, 1, 15, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected due date: %v", todo.DueDate)
+	}
+
+	if todo.IsAllDay != nil {
+		t.Error("expected a timed task")
+	}
+
+	if *todo.RepeatFlag != "RRULE:FREQ=WEEKLY" || *todo.Priority != ticktick.PriorityHigh {
+		t.Errorf("unexpected repeat or priority: %s, %d", *todo.RepeatFlag, *todo.Priority)
+	}
+
+	if len(todo.Tags) != 2 || todo.Tags[1] != "q1,2024" {
+		t.Errorf("unexpected tags: %v", todo.Tags)
+	}
+
+	if len(todo.Reminders) != 1 || todo.Reminders[0] != "TRIGGER:-PT15M" {
+		t.Errorf("expected only the relative reminder, got %v", todo.Reminders)
+	}
+
+	if len(todo.Items) != 1 || todo.Items[0].Title != "Outline" ||
+		*todo.Items[0].Status != ticktick.ChecklistStatusCompleted {
+		t.Errorf("unexpected items: %+v", todo.Items)
+	}
+
+	event := entries[1].Request
+
+	if event.IsAllDay == nil || !*event.IsAllDay || event.StartDate.Day() != 1 || event.DueDate.Day() != 2 {
+		t.Errorf("expected all-day Feb 1-2, got %v - %v", event.StartDate, event.DueDate)
+	}
+
+	if *event.Priority != ticktick.PriorityLow {
+		t.Errorf("expected low priority, got %d", *event.Priority)
+	}
+
+	if !entries[2].Completed || entries[0].Completed {
+		t.Error("expected only the last entry to be completed")
+	}
+}
+
+func TestEntriesRoundTrip(t *testing.T) {
+	data := &ticktick.ProjectData{Tasks: []ticktick.Task{{
+		ID:         "t1",
+		Title:      "Weekly sync",
+		DueDate:    ticktick.Time{Time: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+		Priority:   ticktick.PriorityMedium,
+		RepeatFlag: "ERULE:NAME=CUSTOM;BYDATE=20240115",
+		Items:      []ticktick.ChecklistItem{{ID: "i1", Title: "Agenda"}},
+	}}}
+
+	var buf bytes.Buffer
+	ical.Export(&buf, data)
+
+	cal, err := ical.Parse(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := ical.Entries(cal, "proj1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+
+	req := entries[0].Request
+
+	if entries[0].UID != "t1" || req.Title != "Weekly sync" || *req.Priority != ticktick.PriorityMedium {
+		t.Errorf("unexpected entry: %+v", entries[0])
+	}
+
+	if *req.RepeatFlag != "ERULE:NAME=CUSTOM;BYDATE=20240115" {
+		t.Errorf("unexpected repeat flag: %s", *req.RepeatFlag)
+	}
+
+	if len(req.Items) != 1 || req.Items[0].Title != "Agenda" {
+		t.Errorf("unexpected items: %+v", req.Items)
+	}
+}
+
+// importServer records created and completed tasks. The project already
+// contains a task with ID "done-1".
+func importServer(t *testing.T, created *[]ticktick.CreateTaskRequest, completed *[]string) *ticktick.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/open/v1/project/proj1/data":
+			json.NewEncoder(w).Encode(ticktick.ProjectData{Tasks: []ticktick.Task{{ID: "done-1"}}})
+		case r.URL.Path == "/open/v1/task":
+			var req ticktick.CreateTaskRequest
+			json.NewDecoder(r.Body).Decode(&req)
+
+			*created = append(*created, req)
+
+			json.NewEncoder(w).Encode(ticktick.Task{ID: "new-" + req.Title, ProjectID: req.ProjectID})
+		case strings.HasSuffix(r.URL.Path, "/complete"):
+			*completed = append(*completed, r.URL.Path)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return ticktick.NewClient("token", ticktick.WithBaseURL(server.URL))
+}
+
+func TestImport(t *testing.T) {
+	var (
+		created   []ticktick.CreateTaskRequest
+		completed []string
+	)
+
+	client := importServer(t, &created, &completed)
+	imported := map[string]string{"event-1": "old"}
+
+	result, err := ical.Import(context.Background(), client, strings.NewReader(calendar), ical.ImportOptions{
+		ProjectID: "proj1",
+		Imported:  imported,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(created) != 1 || created[0].Title != "Write report, draft" {
+		t.Fatalf("expected only todo-1 to be created, got %+v", created)
+	}
+
+	if len(result.Skipped) != 2 || result.Skipped[0] != "event-1" || result.Skipped[1] != "done-1" {
+		t.Errorf("unexpected skipped: %v", result.Skipped)
+	}
+
+	if imported["todo-1"] != "new-Write report, draft" {
+		t.Errorf("expected todo-1 to be recorded, got %v", imported)
+	}
+
+	if len(completed) != 0 {
+		t.Errorf("expected no completions, got %v", completed)
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	var (
+		created   []ticktick.CreateTaskRequest
+		completed []string
+	)
+
+	client := importServer(t, &created, &completed)
+
+	result, err := ical.Import(context.Background(), client, strings.NewReader(calendar), ical.ImportOptions{
+		ProjectID: "proj1",
+		DryRun:    true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(created) != 0 {
+		t.Errorf("expected no requests in a dry run, got %d", len(created))
+	}
+
+	if len(result.Created) != 2 || result.Created[0].Task != nil || result.Created[1].Request.Title != "Conference" {
+		t.Errorf("unexpected dry-run result: %+v", result.Created)
+	}
+}
+
+func TestImportCompletes(t *testing.T) {
+	var (
+		created   []ticktick.CreateTaskRequest
+		completed []string
+	)
+
+	client := importServer(t, &created, &completed)
+
+	const data = "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:x\r\nSUMMARY:Done\r\nSTATUS:COMPLETED\r\n" +
+		"END:VTODO\r\nEND:VCALENDAR\r\n"
+
+	result, err := ical.Import(context.Background(), client, strings.NewReader(data), ical.ImportOptions{
+		ProjectID: "proj1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(completed) != 1 || completed[0] != "/open/v1/project/proj1/task/new-Done/complete" {
+		t.Errorf("unexpected completions: %v", completed)
+	}
+
+	if result.Created[0].Task.Status != ticktick.TaskStatusCompleted {
+		t.Error("expected the created task to be marked completed")
+	}
+}
@@ -0,0 +1,151 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrSyntax is returned by [Parse] for malformed iCalendar data.
+var ErrSyntax = errors.New("ical: syntax error")
+
+// Parse reads iCalendar data and returns its top-level component, normally a
+// VCALENDAR. Folded lines are unfolded; property values are kept encoded.
+func Parse(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		root  *Component
+		stack []*Component
+	)
+
+	for i, line := range lines {
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrSyntax, i+1, err)
+		}
+
+		switch strings.ToUpper(prop.Name) {
+		case "BEGIN":
+			c := &Component{Name: strings.ToUpper(prop.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, c)
+			} else if root == nil {
+				root = c
+			}
+
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return nil, fmt.Errorf("%w: line %d: unexpected END:%s", ErrSyntax, i+1, prop.Value)
+			}
+
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: line %d: property outside a component", ErrSyntax, i+1)
+			}
+
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, prop)
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%w: missing END:%s", ErrSyntax, stack[len(stack)-1].Name)
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("%w: no component", ErrSyntax)
+	}
+
+	return root, nil
+}
+
+// UnescapeText reverses [EscapeText].
+func UnescapeText(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
+
+// unfold reads the content lines, joining continuation lines that start with
+// a space or tab. Blank lines are dropped.
+func unfold(r io.Reader) ([]string, error) {
+	// Unfolded DESCRIPTION values can exceed bufio's default line limit.
+	const maxLineLen = 1 << 20
+
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLen)
+
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ical: read: %w", err)
+	}
+
+	return lines, nil
+}
+
+// parseLine splits a content line into name, parameters and value. Colons
+// and semicolons inside quoted parameter values do not count as separators.
+func parseLine(line string) (Property, error) {
+	var (
+		fields  []string
+		start   int
+		quoted  bool
+		valueAt = -1
+	)
+
+	for i := 0; i < len(line) && valueAt < 0; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				fields = append(fields, line[start:i])
+				start = i + 1
+			}
+		case ':':
+			if !quoted {
+				fields = append(fields, line[start:i])
+				valueAt = i + 1
+			}
+		}
+	}
+
+	if valueAt < 0 || fields[0] == "" {
+		return Property{}, fmt.Errorf("malformed content line %q", line)
+	}
+
+	prop := Property{Name: strings.ToUpper(fields[0]), Value: line[valueAt:]}
+
+	for _, field := range fields[1:] {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return Property{}, fmt.Errorf("malformed parameter %q", field)
+		}
+
+		prop.Params = append(prop.Params, Param{Name: strings.ToUpper(name), Value: strings.Trim(value, `"`)})
+	}
+
+	return prop, nil
+}
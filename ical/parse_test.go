@@ -0,0 +1,79 @@
+package ical_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick/ical"
+)
+
+func TestParse(t *testing.T) {
+	const data = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:t1\r\n" +
+		"SUMMARY:A long\r\n  summary\r\n" +
+		"DTSTART;TZID=\"Europe/Berlin\":20240115T090000\r\n" +
+		"X-ODD;X-P=\"a;b:c\":value:with:colons\r\n" +
+		"BEGIN:VALARM\r\nTRIGGER:-PT5M\r\nEND:VALARM\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	cal, err := ical.Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cal.Name != "VCALENDAR" || len(cal.Components) != 1 {
+		t.Fatalf("unexpected calendar: %+v", cal)
+	}
+
+	todo := cal.Components[0]
+
+	if p := todo.Get("SUMMARY"); p == nil || p.Value != "A long summary" {
+		t.Errorf("expected unfolded summary, got %+v", p)
+	}
+
+	if p := todo.Get("DTSTART"); p == nil || p.Param("TZID") != "Europe/Berlin" || p.Value != "20240115T090000" {
+		t.Errorf("unexpected DTSTART: %+v", p)
+	}
+
+	if p := todo.Get("X-ODD"); p == nil || p.Param("X-P") != "a;b:c" || p.Value != "value:with:colons" {
+		t.Errorf("unexpected X-ODD: %+v", p)
+	}
+
+	if len(todo.Components) != 1 || todo.Components[0].Get("TRIGGER").Value != "-PT5M" {
+		t.Errorf("unexpected alarms: %+v", todo.Components)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"unclosed", "BEGIN:VCALENDAR\r\n"},
+		{"mismatched end", "BEGIN:VCALENDAR\r\nEND:VTODO\r\n"},
+		{"no colon", "BEGIN:VCALENDAR\r\nSUMMARY\r\nEND:VCALENDAR\r\n"},
+		{"outside component", "SUMMARY:x\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ical.Parse(strings.NewReader(tt.data))
+			if !errors.Is(err, ical.ErrSyntax) {
+				t.Errorf("expected ErrSyntax, got %v", err)
+			}
+		})
+	}
+}
+
+func TestUnescapeText(t *testing.T) {
+	s := "a,b;c\\d\ne"
+
+	if got := ical.UnescapeText(ical.EscapeText(s)); got != s {
+		t.Errorf("expected %q, got %q", s, got)
+	}
+}
@@ -0,0 +1,120 @@
+package ticktick
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// InboxProjectID is the alias the API accepts in place of the Inbox project's
+// real ID, which has the form "inbox<userId>".
+const InboxProjectID = "inbox"
+
+// ErrInboxUnsupported is returned by [Client.Inbox] when the API does not
+// expose the Inbox for the authenticated account.
+var ErrInboxUnsupported = errors.New("ticktick: inbox is not available through the API")
+
+// IsInboxID reports whether projectID refers to the Inbox, either by its
+// "inbox<userId>" ID, where the user ID is numeric, or by [InboxProjectID].
+// Regular project IDs are hexadecimal and never take that form.
+func IsInboxID(projectID string) bool {
+	userID, ok := strings.CutPrefix(projectID, InboxProjectID)
+
+	return ok && strings.TrimLeft(userID, "0123456789") == ""
+}
+
+// Inbox retrieves the Inbox along with its tasks. The Inbox does not appear in
+// [Client.GetProjects]; its ID is discovered from the response and cached, and
+// Project.Name is set to "Inbox" when the API leaves it empty. If the API
+// rejects the Inbox or answers with an empty body, the error wraps
+// [ErrInboxUnsupported] and the [*Error].
+func (c *Client) Inbox(ctx context.Context) (*ProjectData, error) {
+	data, err := c.GetProjectData(ctx, c.cachedInboxID())
+	if err != nil {
+		if inboxUnsupported(err) {
+			return nil, fmt.Errorf("%w: %w", ErrInboxUnsupported, err)
+		}
+
+		return nil, err
+	}
+
+	id := discoverInboxID(data)
+	if id != "" {
+		c.inboxMu.Lock()
+		c.inboxID = id
+		c.inboxMu.Unlock()
+	}
+
+	data.Project.ID = c.cachedInboxID()
+
+	if data.Project.Name == "" {
+		data.Project.Name = "Inbox"
+	}
+
+	if data.Project.Kind == "" {
+		data.Project.Kind = ProjectKindTask
+	}
+
+	return data, nil
+}
+
+// InboxID returns the Inbox project ID for use with CreateTask, GetProjectData
+// and other project-scoped calls. It is "inbox<userId>" once discovered and
+// [InboxProjectID] while the Inbox has no tasks to reveal it; the API accepts
+// both.
+func (c *Client) InboxID(ctx context.Context) (string, error) {
+	if id := c.cachedInboxID(); id != InboxProjectID {
+		return id, nil
+	}
+
+	data, err := c.Inbox(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return data.Project.ID, nil
+}
+
+func (c *Client) cachedInboxID() string {
+	c.inboxMu.Lock()
+	defer c.inboxMu.Unlock()
+
+	if c.inboxID == "" {
+		return InboxProjectID
+	}
+
+	return c.inboxID
+}
+
+// discoverInboxID returns the "inbox<userId>" ID from the project or any of its
+// tasks, or "" if none carries it.
+func discoverInboxID(data *ProjectData) string {
+	if data.Project.ID != InboxProjectID && IsInboxID(data.Project.ID) {
+		return data.Project.ID
+	}
+
+	for _, task := range data.Tasks {
+		if task.ProjectID != InboxProjectID && IsInboxID(task.ProjectID) {
+			return task.ProjectID
+		}
+	}
+
+	return ""
+}
+
+// inboxUnsupported reports whether err is the API's answer to an Inbox it
+// does not expose.
+func inboxUnsupported(err error) bool {
+	if IsNotFound(err) {
+		return true
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusForbidden
+}
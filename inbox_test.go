@@ -0,0 +1,111 @@
+package ticktick_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+func TestInbox(t *testing.T) {
+	var paths []string
+
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+
+		json.NewEncoder(w).Encode(ticktick.ProjectData{
+			Tasks: []ticktick.Task{{ID: "t1", ProjectID: "inbox123"}},
+		})
+	})
+	defer server.Close()
+
+	data, err := client.Inbox(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if data.Project.ID != "inbox123" {
+		t.Errorf("expected inbox123, got %s", data.Project.ID)
+	}
+
+	if data.Project.Name != "Inbox" {
+		t.Errorf("expected Inbox, got %s", data.Project.Name)
+	}
+
+	if len(data.Tasks) != 1 {
+		t.Errorf("expected 1 task, got %d", len(data.Tasks))
+	}
+
+	id, err := client.InboxID(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if id != "inbox123" {
+		t.Errorf("expected inbox123, got %s", id)
+	}
+
+	if len(paths) != 1 || paths[0] != "/open/v1/project/inbox/data" {
+		t.Errorf("expected one request for the inbox alias, got %v", paths)
+	}
+}
+
+func TestInboxIDEmptyInbox(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(ticktick.ProjectData{})
+	})
+	defer server.Close()
+
+	id, err := client.InboxID(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if id != ticktick.InboxProjectID {
+		t.Errorf("expected %s, got %s", ticktick.InboxProjectID, id)
+	}
+}
+
+func TestInboxUnsupported(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	_, err := client.Inbox(context.Background())
+	if !errors.Is(err, ticktick.ErrInboxUnsupported) {
+		t.Fatalf("expected ErrInboxUnsupported, got %v", err)
+	}
+
+	var apiErr *ticktick.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected wrapped 404 *ticktick.Error, got %v", err)
+	}
+}
+
+func TestInboxUnsupportedEmptyBody(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	_, err := client.Inbox(context.Background())
+	if !errors.Is(err, ticktick.ErrInboxUnsupported) {
+		t.Fatalf("expected ErrInboxUnsupported, got %v", err)
+	}
+}
+
+func TestIsInboxID(t *testing.T) {
+	if !ticktick.IsInboxID("inbox123") || !ticktick.IsInboxID(ticktick.InboxProjectID) {
+		t.Error("expected inbox IDs to be recognized")
+	}
+
+	for _, id := range []string{"proj1", "inboxes", "inbox-list"} {
+		if ticktick.IsInboxID(id) {
+			t.Errorf("expected %s not to be an inbox ID", id)
+		}
+	}
+}
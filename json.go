@@ -0,0 +1,247 @@
+package ticktick
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// UnmarshalJSON decodes a task. Dates are placed in the IANA time zone named by
+// TimeZone, so that the wall-clock values match what the TickTick apps show;
+// checklist items without their own time zone inherit the task's. Members the
+// Task type does not model are kept in Extra.
+func (t *Task) UnmarshalJSON(data []byte) error {
+	type alias Task
+
+	var a alias
+
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	extra, err := unknownMembers(data, reflect.TypeFor[alias]())
+	if err != nil {
+		return err
+	}
+
+	*t = Task(a)
+	t.Extra = extra
+
+	if loc := loadLocation(t.TimeZone); loc != nil {
+		inLocation(loc, &t.StartDate, &t.DueDate, &t.CompletedTime)
+
+		for i := range t.Items {
+			if t.Items[i].TimeZone == "" {
+				inLocation(loc, &t.Items[i].StartDate, &t.Items[i].CompletedTime)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes a task, including the members kept in Extra.
+func (t Task) MarshalJSON() ([]byte, error) {
+	type alias Task
+
+	return marshalWithExtra(alias(t), t.Extra)
+}
+
+// UnmarshalJSON decodes a checklist item and places its dates in the IANA time
+// zone named by TimeZone.
+func (i *ChecklistItem) UnmarshalJSON(data []byte) error {
+	type alias ChecklistItem
+
+	var a alias
+
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	*i = ChecklistItem(a)
+
+	if loc := loadLocation(i.TimeZone); loc != nil {
+		inLocation(loc, &i.StartDate, &i.CompletedTime)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON decodes a project. Members the Project type does not model are kept in Extra.
+func (p *Project) UnmarshalJSON(data []byte) error {
+	type alias Project
+
+	var a alias
+
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	extra, err := unknownMembers(data, reflect.TypeFor[alias]())
+	if err != nil {
+		return err
+	}
+
+	*p = Project(a)
+	p.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON encodes a project, including the members kept in Extra.
+func (p Project) MarshalJSON() ([]byte, error) {
+	type alias Project
+
+	return marshalWithExtra(alias(p), p.Extra)
+}
+
+// MarshalJSON encodes the request. If TimeZone is unset, it is filled from the
+// location of StartDate or DueDate when that location is a named IANA zone.
+func (r CreateTaskRequest) MarshalJSON() ([]byte, error) {
+	type alias CreateTaskRequest
+
+	a := alias(r)
+	if a.TimeZone == nil {
+		a.TimeZone = timeZoneOf(a.StartDate, a.DueDate)
+	}
+
+	return json.Marshal(a)
+}
+
+// MarshalJSON encodes the request, including the members kept in Extra. If
+// TimeZone is unset, it is filled from the location of StartDate or DueDate
+// when that location is a named IANA zone.
+func (r UpdateTaskRequest) MarshalJSON() ([]byte, error) {
+	type alias UpdateTaskRequest
+
+	a := alias(r)
+	if a.TimeZone == nil {
+		a.TimeZone = timeZoneOf(a.StartDate, a.DueDate)
+	}
+
+	return marshalWithExtra(a, r.Extra)
+}
+
+// MarshalJSON encodes the request. If TimeZone is unset, it is filled from the
+// location of StartDate when that location is a named IANA zone.
+func (r CreateChecklistItemRequest) MarshalJSON() ([]byte, error) {
+	type alias CreateChecklistItemRequest
+
+	a := alias(r)
+	if a.TimeZone == nil {
+		a.TimeZone = timeZoneOf(a.StartDate)
+	}
+
+	return json.Marshal(a)
+}
+
+// MarshalJSON encodes the request, including the members kept in Extra.
+func (r UpdateProjectRequest) MarshalJSON() ([]byte, error) {
+	type alias UpdateProjectRequest
+
+	return marshalWithExtra(alias(r), r.Extra)
+}
+
+// ETag returns the task's entity tag, taken from Extra.
+func (t *Task) ETag() string {
+	return extraValue[string](t.Extra, "etag")
+}
+
+// ModifiedTime returns when the task was last modified, taken from Extra.
+func (t *Task) ModifiedTime() Time {
+	return extraValue[Time](t.Extra, "modifiedTime")
+}
+
+// ETag returns the project's entity tag, taken from Extra.
+func (p *Project) ETag() string {
+	return extraValue[string](p.Extra, "etag")
+}
+
+// extraValue decodes the member key of extra into a T. It returns the zero
+// value if the member is missing or has an unexpected type.
+func extraValue[T any](extra map[string]json.RawMessage, key string) T {
+	var v T
+
+	raw, ok := extra[key]
+	if !ok {
+		return v
+	}
+
+	if err := json.Unmarshal(raw, &v); err != nil {
+		var zero T
+
+		return zero
+	}
+
+	return v
+}
+
+// unknownMembers returns the members of the JSON object in data that do not
+// correspond to a field of the struct type typ, or nil if there are none.
+func unknownMembers(data []byte, typ reflect.Type) (map[string]json.RawMessage, error) {
+	var members map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	for name := range jsonFieldNames(typ) {
+		delete(members, name)
+	}
+
+	if len(members) == 0 {
+		members = nil
+	}
+
+	return members, nil
+}
+
+// marshalWithExtra encodes v and adds the members of extra that do not
+// correspond to a field of v. Fields of v own their members: a set field is
+// encoded from v and an omitted one is left out, so a stale copy in extra is
+// never sent in its place.
+func marshalWithExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var members map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	fields := jsonFieldNames(reflect.TypeOf(v))
+
+	for key, value := range extra {
+		if _, ok := fields[key]; !ok {
+			members[key] = value
+		}
+	}
+
+	return json.Marshal(members)
+}
+
+// jsonFieldNames returns the JSON member names of the struct type typ.
+func jsonFieldNames(typ reflect.Type) map[string]struct{} {
+	names := make(map[string]struct{}, typ.NumField())
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+
+		names[name] = struct{}{}
+	}
+
+	return names
+}
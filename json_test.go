@@ -0,0 +1,207 @@
+package ticktick_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+const taskWithExtra = `{
+	"id": "task1",
+	"projectId": "proj1",
+	"title": "Task",
+	"columnId": "col1",
+	"parentId": "parent1",
+	"tags": ["work", "urgent"],
+	"etag": "abc123",
+	"modifiedTime": "2024-01-15T10:00:00.000+0000"
+}`
+
+func TestTaskExtraFields(t *testing.T) {
+	var task ticktick.Task
+
+	if err := json.Unmarshal([]byte(taskWithExtra), &task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(task.Extra) != 2 {
+		t.Errorf("expected 2 extra members, got %d: %v", len(task.Extra), task.Extra)
+	}
+
+	if _, ok := task.Extra["title"]; ok {
+		t.Error("known member title should not be in Extra")
+	}
+
+	if task.ColumnID != "col1" {
+		t.Errorf("expected column col1, got %q", task.ColumnID)
+	}
+
+	if task.ParentID != "parent1" {
+		t.Errorf("expected parent parent1, got %q", task.ParentID)
+	}
+
+	if tags := task.Tags; len(tags) != 2 || tags[0] != "work" || tags[1] != "urgent" {
+		t.Errorf("unexpected tags: %v", tags)
+	}
+
+	if task.ETag() != "abc123" {
+		t.Errorf("expected etag abc123, got %q", task.ETag())
+	}
+
+	if task.ModifiedTime().Hour() != 10 {
+		t.Errorf("unexpected modified time: %v", task.ModifiedTime())
+	}
+}
+
+func TestTaskExtraRoundTrip(t *testing.T) {
+	var task ticktick.Task
+
+	if err := json.Unmarshal([]byte(taskWithExtra), &task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task.Title = "Renamed"
+	task.Extra["title"] = json.RawMessage(`"ignored"`)
+
+	data, err := json.Marshal(task)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded["title"] != "Renamed" {
+		t.Errorf("expected typed field to take precedence, got %v", decoded["title"])
+	}
+
+	if decoded["modifiedTime"] != "2024-01-15T10:00:00.000+0000" || decoded["etag"] != "abc123" {
+		t.Errorf("expected extra members to be re-emitted, got %v", decoded)
+	}
+}
+
+func TestTaskWithoutExtra(t *testing.T) {
+	var task ticktick.Task
+
+	if err := json.Unmarshal([]byte(`{"id":"task1"}`), &task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.Extra != nil {
+		t.Errorf("expected nil Extra, got %v", task.Extra)
+	}
+
+	if task.ETag() != "" || !task.ModifiedTime().IsZero() {
+		t.Error("expected zero accessor values")
+	}
+}
+
+func TestProjectExtraRoundTrip(t *testing.T) {
+	var project ticktick.Project
+
+	err := json.Unmarshal([]byte(`{"id":"proj1","name":"Work","etag":"e1","teamId":"team1"}`), &project)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if project.ETag() != "e1" || string(project.Extra["teamId"]) != `"team1"` {
+		t.Errorf("unexpected extra: %v", project.Extra)
+	}
+
+	data, err := json.Marshal(&project)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded["teamId"] != "team1" || decoded["name"] != "Work" {
+		t.Errorf("unexpected round trip: %v", decoded)
+	}
+}
+
+func TestUpdateTaskCarriesExtra(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		if body["columnId"] != "col1" {
+			t.Errorf("expected columnId col1, got %v", body["columnId"])
+		}
+
+		if body["title"] != "Updated" {
+			t.Errorf("expected title Updated, got %v", body["title"])
+		}
+
+		w.Write([]byte(taskWithExtra))
+	})
+	defer server.Close()
+
+	var task ticktick.Task
+
+	if err := json.Unmarshal([]byte(taskWithExtra), &task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := client.UpdateTask(context.Background(), task.ID, &ticktick.UpdateTaskRequest{
+		ID:        task.ID,
+		ProjectID: task.ProjectID,
+		Title:     ticktick.String("Updated"),
+		ColumnID:  ticktick.String(task.ColumnID),
+		Extra:     task.Extra,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUpdateProjectCarriesExtra(t *testing.T) {
+	req := ticktick.UpdateProjectRequest{
+		Name:  ticktick.String("Work"),
+		Extra: map[string]json.RawMessage{"teamId": json.RawMessage(`"team1"`)},
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(data) != `{"name":"Work","teamId":"team1"}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestUpdateTaskExtraIgnoresTypedFields(t *testing.T) {
+	req := ticktick.UpdateTaskRequest{
+		ID:        "t1",
+		ProjectID: "p1",
+		Title:     ticktick.String("Fresh"),
+		Extra: map[string]json.RawMessage{
+			"title":   json.RawMessage(`"Stale title"`),
+			"content": json.RawMessage(`"Stale content"`),
+			"etag":    json.RawMessage(`"e1"`),
+		},
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(data) != `{"etag":"e1","id":"t1","projectId":"p1","title":"Fresh"}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
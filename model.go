@@ -1,6 +1,7 @@
 package ticktick
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -78,6 +79,14 @@ type Task struct {
 	Status        int             `json:"status"`
 	TimeZone      string          `json:"timeZone"`
 	Kind          string          `json:"kind"`
+	ColumnID      string          `json:"columnId"`
+	ParentID      string          `json:"parentId"`
+	ChildIDs      []string        `json:"childIds"`
+	Tags          []string        `json:"tags"`
+
+	// Extra holds the members of the API response that Task does not model,
+	// such as "etag" or "modifiedTime". They are re-emitted when the task is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ChecklistItem represents a subtask within a task.
@@ -103,6 +112,10 @@ type Project struct {
 	ViewMode   string `json:"viewMode"`
 	Permission string `json:"permission"`
 	Kind       string `json:"kind"`
+
+	// Extra holds the members of the API response that Project does not model,
+	// such as "etag". They are re-emitted when the project is marshaled.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Column represents a kanban column within a project.
@@ -135,6 +148,10 @@ type CreateTaskRequest struct {
 	Priority   *int                         `json:"priority,omitempty"`
 	SortOrder  *int64                       `json:"sortOrder,omitempty"`
 	Items      []CreateChecklistItemRequest `json:"items,omitzero"`
+	ColumnID   *string                      `json:"columnId,omitempty"`
+	ParentID   *string                      `json:"parentId,omitempty"`
+	Tags       []string                     `json:"tags,omitzero"`
+	Kind       *string                      `json:"kind,omitempty"`
 }
 
 // UpdateTaskRequest contains the fields for updating an existing task.
@@ -153,6 +170,16 @@ type UpdateTaskRequest struct {
 	Priority   *int                         `json:"priority,omitempty"`
 	SortOrder  *int64                       `json:"sortOrder,omitempty"`
 	Items      []CreateChecklistItemRequest `json:"items,omitzero"`
+	ColumnID   *string                      `json:"columnId,omitempty"`
+	ParentID   *string                      `json:"parentId,omitempty"`
+	Tags       []string                     `json:"tags,omitzero"`
+	Kind       *string                      `json:"kind,omitempty"`
+
+	// Extra holds additional members to send, typically [Task.Extra] of the task
+	// being updated so that fields this library does not model are preserved.
+	// Members named after a typed field are ignored; leave the field nil to keep
+	// the task's value.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // CreateChecklistItemRequest contains the fields for a subtask in a create or update request.
@@ -182,6 +209,18 @@ type UpdateProjectRequest struct {
 	SortOrder *int64  `json:"sortOrder,omitempty"`
 	ViewMode  *string `json:"viewMode,omitempty"`
 	Kind      *string `json:"kind,omitempty"`
+
+	// Extra holds additional members to send, typically [Project.Extra] of the
+	// project being updated. Members named after a typed field are ignored.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// CompletedTasksRequest selects completed tasks by project and completion time.
+// Empty fields are not used for filtering.
+type CompletedTasksRequest struct {
+	ProjectIDs []string `json:"projectIds,omitzero"`
+	StartDate  *Time    `json:"startDate,omitempty"`
+	EndDate    *Time    `json:"endDate,omitempty"`
 }
 
 // Time wraps [time.Time] with custom JSON marshaling for the TickTick API date format.
@@ -0,0 +1,210 @@
+package ticktick
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Note is a task in a note-kind project. It exposes only the fields that are
+// meaningful for notes; dates, reminders and priority are left out.
+type Note struct {
+	ID        string
+	ProjectID string
+	Title     string
+	Content   string
+	Tags      []string
+	SortOrder int64
+}
+
+// CreateNoteRequest contains the fields for creating a new note.
+type CreateNoteRequest struct {
+	ProjectID string
+	Title     string
+	Content   string
+	Tags      []string
+}
+
+// UpdateNoteRequest contains the fields for updating an existing note.
+// Nil fields are left unchanged.
+type UpdateNoteRequest struct {
+	ID        string
+	ProjectID string
+	Title     *string
+	Content   *string
+	Tags      []string
+}
+
+// NoteFromTask converts a task to a Note, dropping the fields notes do not use.
+func NoteFromTask(t *Task) Note {
+	return Note{
+		ID:        t.ID,
+		ProjectID: t.ProjectID,
+		Title:     t.Title,
+		Content:   t.Content,
+		Tags:      t.Tags,
+		SortOrder: t.SortOrder,
+	}
+}
+
+// NoteProjects returns the note-kind projects of the authenticated user.
+func (c *Client) NoteProjects(ctx context.Context) ([]Project, error) {
+	projects, err := c.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []Project
+
+	for _, p := range projects {
+		if p.Kind == ProjectKindNote {
+			notes = append(notes, p)
+		}
+	}
+
+	return notes, nil
+}
+
+// ListNotes returns the notes in a project. For a note-kind project every task
+// is returned; for other projects only tasks of kind NOTE are.
+func (c *Client) ListNotes(ctx context.Context, projectID string) ([]Note, error) {
+	data, err := c.GetProjectData(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := make([]Note, 0, len(data.Tasks))
+
+	for i := range data.Tasks {
+		if data.Project.Kind == ProjectKindNote || data.Tasks[i].Kind == TaskKindNote {
+			notes = append(notes, NoteFromTask(&data.Tasks[i]))
+		}
+	}
+
+	return notes, nil
+}
+
+// CreateNote creates a new note.
+func (c *Client) CreateNote(ctx context.Context, req *CreateNoteRequest) (*Note, error) {
+	if req == nil {
+		req = &CreateNoteRequest{}
+	}
+
+	task, err := c.CreateTask(ctx, &CreateTaskRequest{
+		Title:     req.Title,
+		ProjectID: req.ProjectID,
+		Content:   String(req.Content),
+		Tags:      req.Tags,
+		Kind:      String(TaskKindNote),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	note := NoteFromTask(task)
+
+	return &note, nil
+}
+
+// UpdateNote updates an existing note.
+func (c *Client) UpdateNote(ctx context.Context, req *UpdateNoteRequest) (*Note, error) {
+	if req == nil {
+		req = &UpdateNoteRequest{}
+	}
+
+	task, err := c.UpdateTask(ctx, req.ID, &UpdateTaskRequest{
+		ID:        req.ID,
+		ProjectID: req.ProjectID,
+		Title:     req.Title,
+		Content:   req.Content,
+		Tags:      req.Tags,
+		Kind:      String(TaskKindNote),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	note := NoteFromTask(task)
+
+	return &note, nil
+}
+
+// AppendToNote appends text to a note's content as a new paragraph. It reads
+// the current content and writes it back with text appended; only the content
+// is changed, and the members of [Task.Extra] are sent back so that fields
+// this library does not model are preserved. Appends to the same note through
+// the same Client are serialized so that they are not lost; the API offers no
+// way to guard against other clients or processes writing in between.
+func (c *Client) AppendToNote(ctx context.Context, projectID, noteID, text string) (*Note, error) {
+	unlock := c.lockNote(noteID)
+	defer unlock()
+
+	task, err := c.GetTask(ctx, projectID, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := c.UpdateTask(ctx, noteID, &UpdateTaskRequest{
+		ID:        noteID,
+		ProjectID: projectID,
+		Content:   String(appendParagraph(task.Content, text)),
+		Extra:     task.Extra,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	note := NoteFromTask(updated)
+
+	return &note, nil
+}
+
+// noteLock is the mutex serializing appends to one note, with the number of
+// appends holding or waiting for it.
+type noteLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lockNote locks the mutex serializing appends to the note with the given ID
+// and returns a function unlocking it.
+func (c *Client) lockNote(noteID string) func() {
+	c.noteMu.Lock()
+
+	if c.noteLocks == nil {
+		c.noteLocks = make(map[string]*noteLock)
+	}
+
+	l, ok := c.noteLocks[noteID]
+	if !ok {
+		l = &noteLock{}
+		c.noteLocks[noteID] = l
+	}
+
+	l.refs++
+	c.noteMu.Unlock()
+
+	l.mu.Lock()
+
+	return func() {
+		l.mu.Unlock()
+
+		c.noteMu.Lock()
+		defer c.noteMu.Unlock()
+
+		l.refs--
+		if l.refs == 0 {
+			delete(c.noteLocks, noteID)
+		}
+	}
+}
+
+// appendParagraph joins content and text with a blank line between them.
+func appendParagraph(content, text string) string {
+	content = strings.TrimRight(content, "\n")
+	if content == "" {
+		return text
+	}
+
+	return content + "\n\n" + text
+}
@@ -0,0 +1,252 @@
+package ticktick_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+func TestNoteProjects(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode([]ticktick.Project{
+			{ID: "proj1", Kind: ticktick.ProjectKindTask},
+			{ID: "notes1", Kind: ticktick.ProjectKindNote},
+		})
+	})
+	defer server.Close()
+
+	projects, err := client.NoteProjects(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(projects) != 1 || projects[0].ID != "notes1" {
+		t.Errorf("unexpected projects: %+v", projects)
+	}
+}
+
+func TestListNotes(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open/v1/project/proj1/data" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		json.NewEncoder(w).Encode(ticktick.ProjectData{
+			Project: ticktick.Project{ID: "proj1", Kind: ticktick.ProjectKindTask},
+			Tasks: []ticktick.Task{
+				{ID: "t1", Kind: ticktick.TaskKindText},
+				{ID: "n1", Kind: ticktick.TaskKindNote, Title: "Ideas", Content: "body", Tags: []string{"x"}},
+			},
+		})
+	})
+	defer server.Close()
+
+	notes, err := client.ListNotes(context.Background(), "proj1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(notes) != 1 || notes[0].ID != "n1" || notes[0].Content != "body" || notes[0].Tags[0] != "x" {
+		t.Errorf("unexpected notes: %+v", notes)
+	}
+}
+
+func TestListNotesNoteProject(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(ticktick.ProjectData{
+			Project: ticktick.Project{ID: "notes1", Kind: ticktick.ProjectKindNote},
+			Tasks:   []ticktick.Task{{ID: "n1"}, {ID: "n2"}},
+		})
+	})
+	defer server.Close()
+
+	notes, err := client.ListNotes(context.Background(), "notes1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(notes) != 2 {
+		t.Errorf("expected 2 notes, got %d", len(notes))
+	}
+}
+
+func TestCreateNote(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		if body["kind"] != ticktick.TaskKindNote || body["content"] != "text" || body["projectId"] != "notes1" {
+			t.Errorf("unexpected body: %v", body)
+		}
+
+		for _, key := range []string{"dueDate", "startDate", "priority", "reminders"} {
+			if _, ok := body[key]; ok {
+				t.Errorf("unexpected %s in note request", key)
+			}
+		}
+
+		json.NewEncoder(w).Encode(ticktick.Task{ID: "n1", ProjectID: "notes1", Title: "Note", Content: "text"})
+	})
+	defer server.Close()
+
+	note, err := client.CreateNote(context.Background(), &ticktick.CreateNoteRequest{
+		ProjectID: "notes1",
+		Title:     "Note",
+		Content:   "text",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if note.ID != "n1" || note.Content != "text" {
+		t.Errorf("unexpected note: %+v", note)
+	}
+
+	if _, err := client.CreateNote(context.Background(), nil); err == nil {
+		t.Error("expected validation error for nil request")
+	}
+}
+
+func TestUpdateNote(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open/v1/task/n1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var req ticktick.UpdateTaskRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		if req.Title == nil || *req.Title != "Renamed" || req.Content != nil {
+			t.Errorf("unexpected request: %+v", req)
+		}
+
+		json.NewEncoder(w).Encode(ticktick.Task{ID: "n1", Title: "Renamed"})
+	})
+	defer server.Close()
+
+	note, err := client.UpdateNote(context.Background(), &ticktick.UpdateNoteRequest{
+		ID:        "n1",
+		ProjectID: "notes1",
+		Title:     ticktick.String("Renamed"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if note.Title != "Renamed" {
+		t.Errorf("unexpected note: %+v", note)
+	}
+}
+
+func TestAppendToNote(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		content = "First line\n"
+	)
+
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"id":"n1","projectId":"notes1","content":` + jsonString(content) + `,"etag":"e1"}`))
+		case http.MethodPost:
+			var body map[string]any
+
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+
+			if body["etag"] != "e1" {
+				t.Errorf("expected unknown fields to be carried, got %v", body)
+			}
+
+			content = body["content"].(string)
+
+			json.NewEncoder(w).Encode(ticktick.Task{ID: "n1", Content: content})
+		}
+	})
+	defer server.Close()
+
+	var wg sync.WaitGroup
+
+	for _, text := range []string{"a", "b", "c"} {
+		wg.Go(func() {
+			if _, err := client.AppendToNote(context.Background(), "notes1", "n1", text); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	parts := strings.Split(content, "\n\n")
+	if len(parts) != 4 || parts[0] != "First line" {
+		t.Errorf("expected every append to be kept, got %q", content)
+	}
+}
+
+func jsonString(s string) string {
+	data, _ := json.Marshal(s)
+
+	return string(data)
+}
+
+func TestAppendToNoteDifferentNotes(t *testing.T) {
+	var (
+		once     sync.Once
+		bothRead = make(chan struct{})
+		reads    sync.WaitGroup
+	)
+
+	reads.Add(2)
+
+	go func() {
+		reads.Wait()
+		close(bothRead)
+	}()
+
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		if r.Method == http.MethodGet {
+			reads.Done()
+
+			// Each read waits for the other note's read, which would never come
+			// if appends to different notes were serialized.
+			select {
+			case <-bothRead:
+			case <-time.After(5 * time.Second):
+				once.Do(func() { t.Error("expected appends to different notes to run concurrently") })
+			}
+		}
+
+		json.NewEncoder(w).Encode(ticktick.Task{ID: id})
+	})
+	defer server.Close()
+
+	var wg sync.WaitGroup
+
+	for _, id := range []string{"n1", "n2"} {
+		wg.Go(func() {
+			if _, err := client.AppendToNote(context.Background(), "notes1", id, "text"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,321 @@
+package org
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// ErrSyntax is returned by [Decode] for malformed timestamps.
+var ErrSyntax = errors.New("org: syntax error")
+
+var (
+	headingPattern  = regexp.MustCompile(`^(\*+)\s+(.*?)\s*$`)
+	keywordPattern  = regexp.MustCompile(`^(TODO|DONE)(?:\s+|$)`)
+	cookiePattern   = regexp.MustCompile(`^\[#([A-Ca-c])\]\s*`)
+	tagsPattern     = regexp.MustCompile(`(?:^|\s+)(:(?:[^\s:]+:)+)$`)
+	planningPattern = regexp.MustCompile(`(CLOSED|DEADLINE|SCHEDULED):\s*([<\[][^>\]]*[>\]])`)
+	planningOnly    = regexp.MustCompile(`^(?:(?:CLOSED|DEADLINE|SCHEDULED):\s*[<\[][^>\]]*[>\]]\s*)+$`)
+	propertyPattern = regexp.MustCompile(`^:([^:\s]+):\s*(.*)$`)
+	checkboxPattern = regexp.MustCompile(`^[-+]\s+\[([ xX-])\]\s+(.*)$`)
+	repeaterPattern = regexp.MustCompile(`^(?:\.\+|\+\+|\+)(\d+)([dwmy])$`)
+)
+
+// heading is a heading line of an outline with the lines below it.
+type heading struct {
+	line  int
+	level int
+	text  string
+	body  []string
+}
+
+// Decode reads an org-mode outline. Level 1 headings without a TODO or DONE
+// keyword are projects, named by the heading without its tags; every other
+// heading is a task, nested under the closest heading above it with a lower
+// level. Text before the first heading is ignored.
+//
+// IDs and time zones come from the property drawers written by [Encode].
+// Timestamps are read in the task's TIMEZONE property or, without one, in loc;
+// a nil loc means UTC. A task with only a DEADLINE starts on its due date,
+// like tasks created in the TickTick apps. Checkbox list items become
+// checklist items, text lines escaped by [Encode] lose their comma, and the
+// task text becomes the description of a checklist task or the content of any
+// other task. Subtasks of tasks without an ID have an empty ParentID; use the
+// returned tree to create them after their parent.
+func Decode(r io.Reader, loc *time.Location) ([]Project, error) {
+	headings, err := readHeadings(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var (
+		projects []Project
+		stack    []*ticktick.TaskNode
+		levels   []int
+	)
+
+	for _, h := range headings {
+		if h.level == 1 && !keywordPattern.MatchString(h.text) {
+			props, _, _ := splitBody(h.body, h.level)
+			name := tagsPattern.ReplaceAllString(h.text, "")
+			projects = append(projects, Project{Project: ticktick.Project{ID: props[propertyID], Name: name}})
+			stack, levels = nil, nil
+
+			continue
+		}
+
+		if len(projects) == 0 {
+			projects = append(projects, Project{})
+		}
+
+		project := &projects[len(projects)-1]
+
+		task, err := decodeTask(h, project.Project.ID, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		for len(levels) > 0 && levels[len(levels)-1] >= h.level {
+			stack, levels = stack[:len(stack)-1], levels[:len(levels)-1]
+		}
+
+		node := &ticktick.TaskNode{Task: task}
+
+		if len(stack) == 0 {
+			project.Tasks = append(project.Tasks, node)
+		} else {
+			parent := stack[len(stack)-1]
+			node.Task.ParentID = parent.Task.ID
+			parent.Children = append(parent.Children, node)
+		}
+
+		stack, levels = append(stack, node), append(levels, h.level)
+	}
+
+	return projects, nil
+}
+
+func readHeadings(r io.Reader) ([]heading, error) {
+	var headings []heading
+
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+
+		if m := headingPattern.FindStringSubmatch(text); m != nil {
+			headings = append(headings, heading{line: line, level: len(m[1]), text: m[2]})
+
+			continue
+		}
+
+		if len(headings) > 0 {
+			h := &headings[len(headings)-1]
+			h.body = append(h.body, text)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("org: read outline: %w", err)
+	}
+
+	return headings, nil
+}
+
+func decodeTask(h heading, projectID string, loc *time.Location) (ticktick.Task, error) {
+	task := ticktick.Task{ProjectID: projectID}
+	text := h.text
+
+	if m := keywordPattern.FindStringSubmatch(text); m != nil {
+		if m[1] == Done {
+			task.Status = ticktick.TaskStatusCompleted
+		}
+
+		text = text[len(m[0]):]
+	}
+
+	if m := cookiePattern.FindStringSubmatch(text); m != nil {
+		task.Priority = priority(m[1])
+		text = text[len(m[0]):]
+	}
+
+	if m := tagsPattern.FindStringSubmatch(text); m != nil {
+		task.Tags = strings.Split(strings.Trim(m[1], ":"), ":")
+		text = text[:len(text)-len(m[0])]
+	}
+
+	task.Title = text
+
+	props, planning, body := splitBody(h.body, h.level)
+	task.ID = props[propertyID]
+	task.TimeZone = props[propertyTimeZone]
+	task.RepeatFlag = props[propertyRepeat]
+
+	if task.TimeZone != "" {
+		if zone, err := time.LoadLocation(task.TimeZone); err == nil {
+			loc = zone
+		}
+	}
+
+	if err := setPlanning(&task, planning, loc); err != nil {
+		return task, fmt.Errorf("%w: line %d: %w", ErrSyntax, h.line, err)
+	}
+
+	var lines []string
+
+	for _, line := range body {
+		trimmed := strings.TrimLeft(line, " \t")
+		if strings.HasPrefix(trimmed, ",") && needsEscape(trimmed) {
+			lines = append(lines, line[:len(line)-len(trimmed)]+trimmed[1:])
+
+			continue
+		}
+
+		m := checkboxPattern.FindStringSubmatch(trimmed)
+		if m == nil {
+			lines = append(lines, line)
+
+			continue
+		}
+
+		item := ticktick.ChecklistItem{Title: m[2]}
+		if m[1] == "x" || m[1] == "X" {
+			item.Status = ticktick.ChecklistStatusCompleted
+		}
+
+		task.Items = append(task.Items, item)
+	}
+
+	text = strings.Trim(strings.Join(lines, "\n"), "\n")
+
+	if len(task.Items) > 0 {
+		task.Kind = ticktick.TaskKindChecklist
+		task.Desc = text
+	} else {
+		task.Content = text
+	}
+
+	return task, nil
+}
+
+// splitBody separates the property drawer and planning line of a heading's
+// body from its text. Text lines lose up to level+1 spaces of indentation.
+func splitBody(body []string, level int) (map[string]string, string, []string) {
+	props := make(map[string]string)
+
+	var (
+		planning string
+		text     []string
+		inDrawer bool
+	)
+
+	for i, line := range body {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case inDrawer && strings.EqualFold(trimmed, ":END:"):
+			inDrawer = false
+		case inDrawer:
+			if m := propertyPattern.FindStringSubmatch(trimmed); m != nil {
+				props[strings.ToUpper(m[1])] = m[2]
+			}
+		case strings.EqualFold(trimmed, ":PROPERTIES:") && len(text) == 0:
+			inDrawer = true
+		case i == 0 && planningOnly.MatchString(trimmed):
+			planning = trimmed
+		default:
+			indent := len(line) - len(strings.TrimLeft(line, " "))
+			text = append(text, line[min(indent, level+1):])
+		}
+	}
+
+	return props, planning, text
+}
+
+func setPlanning(task *ticktick.Task, planning string, loc *time.Location) error {
+	allDay := true
+
+	for _, m := range planningPattern.FindAllStringSubmatch(planning, -1) {
+		t, dateOnly, rep, err := parseTimestamp(m[2], loc)
+		if err != nil {
+			return err
+		}
+
+		switch m[1] {
+		case "CLOSED":
+			task.CompletedTime = ticktick.Time{Time: t}
+
+			continue
+		case "DEADLINE":
+			task.DueDate = ticktick.Time{Time: t}
+		case "SCHEDULED":
+			task.StartDate = ticktick.Time{Time: t}
+		}
+
+		allDay = allDay && dateOnly
+
+		if rep != "" && task.RepeatFlag == "" {
+			task.RepeatFlag = rep
+		}
+	}
+
+	if task.StartDate.IsZero() {
+		task.StartDate = task.DueDate
+	}
+
+	task.IsAllDay = allDay && !task.StartDate.IsZero()
+
+	return nil
+}
+
+// parseTimestamp parses an active or inactive timestamp such as
+// "<2024-01-15 Mon 09:00 +1w>". It reports whether the timestamp has no time
+// of day and returns its repeater as a repeat rule.
+func parseTimestamp(s string, loc *time.Location) (time.Time, bool, string, error) {
+	fields := strings.Fields(strings.Trim(s, "<>[]"))
+	if len(fields) == 0 {
+		return time.Time{}, false, "", fmt.Errorf("empty timestamp %s", s)
+	}
+
+	value, layout, rep := fields[0], "2006-01-02", ""
+
+	for _, f := range fields[1:] {
+		switch {
+		case strings.Contains(f, ":"):
+			clock, _, _ := strings.Cut(f, "-")
+			value, layout = value+" "+clock, "2006-01-02 15:04"
+		case repeaterPattern.MatchString(f):
+			m := repeaterPattern.FindStringSubmatch(f)
+			freq := map[string]string{"d": "DAILY", "w": "WEEKLY", "m": "MONTHLY", "y": "YEARLY"}[m[2]]
+			rep = "RRULE:FREQ=" + freq + ";INTERVAL=" + m[1]
+		}
+	}
+
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return time.Time{}, false, "", fmt.Errorf("invalid timestamp %s: %w", s, err)
+	}
+
+	return t, !strings.Contains(layout, ":"), rep, nil
+}
+
+func priority(cookie string) int {
+	switch strings.ToUpper(cookie) {
+	case "A":
+		return ticktick.PriorityHigh
+	case "B":
+		return ticktick.PriorityMedium
+	default:
+		return ticktick.PriorityLow
+	}
+}
@@ -0,0 +1,147 @@
+package org_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/org"
+)
+
+func TestDecode(t *testing.T) {
+	projects, err := org.Decode(strings.NewReader(testOutline), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(projects) != 1 || projects[0].Project.ID != "p1" || projects[0].Project.Name != "Work" {
+		t.Fatalf("unexpected projects: %+v", projects)
+	}
+
+	roots := projects[0].Tasks
+	if len(roots) != 2 || len(roots[0].Children) != 1 {
+		t.Fatalf("unexpected tree: %+v", roots)
+	}
+
+	release, notes := roots[0].Task, roots[0].Children[0].Task
+
+	if release.ID != "t1" || release.ProjectID != "p1" || release.Priority != ticktick.PriorityHigh ||
+		release.Kind != ticktick.TaskKindChecklist || release.Desc != "Notes are in the wiki.\n\nSee you there." ||
+		strings.Join(release.Tags, ",") != "release,q1_goals" {
+		t.Errorf("unexpected task: %+v", release)
+	}
+
+	if release.RepeatFlag != "RRULE:FREQ=WEEKLY;INTERVAL=1" || release.IsAllDay ||
+		release.StartDate.UTC().Hour() != 8 || release.DueDate.UTC().Hour() != 16 {
+		t.Errorf("unexpected dates: %v %v %s", release.StartDate, release.DueDate, release.RepeatFlag)
+	}
+
+	if len(release.Items) != 2 || release.Items[0].Status != ticktick.ChecklistStatusCompleted ||
+		release.Items[1].Title != "Announce" {
+		t.Errorf("unexpected items: %+v", release.Items)
+	}
+
+	if notes.ParentID != "t1" || notes.Status != ticktick.TaskStatusCompleted || !notes.IsAllDay ||
+		!notes.StartDate.Equal(notes.DueDate.Time) || notes.CompletedTime.Minute() != 30 ||
+		notes.Content != "* not a heading" || notes.RepeatFlag != "RRULE:FREQ=WEEKLY;BYDAY=MO" {
+		t.Errorf("unexpected subtask: %+v", notes)
+	}
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	projects, err := org.Decode(strings.NewReader(testOutline), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data := &ticktick.ProjectData{Project: projects[0].Project}
+	for _, root := range projects[0].Tasks {
+		data.Tasks = append(data.Tasks, root.Task)
+		data.Tasks = append(data.Tasks, root.Descendants()...)
+	}
+
+	for i := range data.Tasks {
+		data.Tasks[i].SortOrder = int64(i)
+	}
+
+	var buf bytes.Buffer
+	if err := org.Encode(&buf, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if buf.String() != testOutline {
+		t.Errorf("expected:\n%s\ngot:\n%s", testOutline, buf.String())
+	}
+}
+
+func TestDecodeRoundTripEscapedText(t *testing.T) {
+	content := "DEADLINE: <2024-01-15 Mon>\n- [ ] buy milk\n  + [X] indented\n, - [x] commas\n:PROPERTIES:\n" +
+		"* not a heading\n, plain"
+	data := &ticktick.ProjectData{Tasks: []ticktick.Task{{Title: "Shop", Content: content}}}
+
+	var buf bytes.Buffer
+	if err := org.Encode(&buf, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "   ,- [ ] buy milk\n") {
+		t.Errorf("expected the checkbox line to be escaped, got:\n%s", buf.String())
+	}
+
+	projects, err := org.Decode(&buf, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task := projects[0].Tasks[0].Task
+	if task.Content != content || len(task.Items) != 0 || !task.DueDate.IsZero() {
+		t.Errorf("unexpected task: %+v", task)
+	}
+}
+
+func TestDecodeForeignOutline(t *testing.T) {
+	const outline = `#+TITLE: Tasks
+* TODO Loose task
+** Plain subheading
+* Home :personal:
+** TODO [#b] Fix sink
+SCHEDULED: <2024-03-01 Fri 8:30-9:30 .+2d>
+- [ ] Buy washer
+  - [x] Find wrench
+`
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	projects, err := org.Decode(strings.NewReader(outline), berlin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(projects) != 2 || projects[0].Project.Name != "" || projects[1].Project.Name != "Home" {
+		t.Fatalf("unexpected projects: %+v", projects)
+	}
+
+	loose := projects[0].Tasks[0]
+	if loose.Task.Title != "Loose task" || len(loose.Children) != 1 ||
+		loose.Children[0].Task.Title != "Plain subheading" {
+		t.Errorf("unexpected loose task: %+v", loose)
+	}
+
+	sink := projects[1].Tasks[0].Task
+	if sink.Priority != ticktick.PriorityMedium || sink.RepeatFlag != "RRULE:FREQ=DAILY;INTERVAL=2" ||
+		sink.StartDate.In(berlin).Hour() != 8 || !sink.DueDate.IsZero() || len(sink.Items) != 2 ||
+		sink.Items[1].Status != ticktick.ChecklistStatusCompleted {
+		t.Errorf("unexpected task: %+v", sink)
+	}
+}
+
+func TestDecodeInvalidTimestamp(t *testing.T) {
+	const outline = "* P\n** TODO Bad\n   DEADLINE: <2024-13-01 Mon>\n"
+
+	if _, err := org.Decode(strings.NewReader(outline), nil); !errors.Is(err, org.ErrSyntax) {
+		t.Errorf("expected ErrSyntax, got %v", err)
+	}
+}
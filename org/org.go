@@ -0,0 +1,323 @@
+// Package org converts TickTick projects and tasks to and from Emacs org-mode
+// outlines, so that tasks kept in org files can be synced in both directions.
+//
+// [Encode] writes a project as a level 1 heading, such as "* Work", with its
+// ID in a property drawer, and its tasks as headings below it, subtasks one
+// level deeper than their parent. A task is written as:
+//
+//	** TODO [#A] Ship release :release:
+//	   DEADLINE: <2024-01-15 Mon 17:00 +1w> SCHEDULED: <2024-01-15 Mon 09:00>
+//	   :PROPERTIES:
+//	   :ID: 6247ee29630c8063a2b3c4d2
+//	   :TIMEZONE: Europe/Berlin
+//	   :END:
+//	   Release notes are in the wiki.
+//	   - [X] Tag the commit
+//	   - [ ] Announce
+//
+// Completed tasks are DONE and get a CLOSED timestamp. Priorities high,
+// medium and low become [#A], [#B] and [#C], the start date SCHEDULED, the
+// due date DEADLINE, and tags heading tags. Daily, weekly, monthly and yearly
+// repeat rules become timestamp repeaters such as "+2w"; other rules are kept
+// in a REPEAT property. Checklist items are checkbox list items. Text lines
+// that would be read as checkbox items, planning lines or property drawers are
+// escaped with a leading comma, as org-mode does inside blocks.
+//
+// [Decode] reads such an outline back, keeping the IDs from the property
+// drawers so that a sync script can tell updated tasks from new ones.
+package org
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// Heading keywords.
+const (
+	Todo = "TODO"
+	Done = "DONE"
+)
+
+// Property names used in drawers.
+const (
+	propertyID       = "ID"
+	propertyTimeZone = "TIMEZONE"
+	propertyRepeat   = "REPEAT"
+)
+
+// taskLevel is the heading level of top-level tasks, below their project.
+const taskLevel = 2
+
+const (
+	dateLayout     = "2006-01-02 Mon"
+	dateTimeLayout = "2006-01-02 Mon 15:04"
+)
+
+var invalidTagChars = regexp.MustCompile(`[^\p{L}\p{N}_@#%]`)
+
+// Project is a project heading of an outline with the tasks below it.
+type Project struct {
+	// Project holds the heading's title and ID. Tasks above the first project
+	// heading, or at level 1 with a keyword, belong to a Project with a zero
+	// Project.
+	Project ticktick.Project
+	Tasks   []*ticktick.TaskNode
+}
+
+// Encode writes the projects and their tasks to w as an org-mode outline.
+func Encode(w io.Writer, projects ...*ticktick.ProjectData) error {
+	var b strings.Builder
+
+	for _, data := range projects {
+		b.WriteString("* " + data.Project.Name + "\n")
+		writeProperties(&b, 1, [][2]string{{propertyID, data.Project.ID}})
+
+		for _, root := range data.TaskTree() {
+			root.Walk(func(node *ticktick.TaskNode, depth int) bool {
+				writeTask(&b, &node.Task, taskLevel+depth)
+
+				return true
+			})
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("org: write outline: %w", err)
+	}
+
+	return nil
+}
+
+// Heading returns the task as an org-mode heading at the given level,
+// including its planning line, property drawer, text and checklist items.
+// Subtasks are not included.
+func Heading(task *ticktick.Task, level int) string {
+	var b strings.Builder
+
+	writeTask(&b, task, level)
+
+	return b.String()
+}
+
+func writeTask(b *strings.Builder, t *ticktick.Task, level int) {
+	keyword := Todo
+	if t.Status == ticktick.TaskStatusCompleted {
+		keyword = Done
+	}
+
+	b.WriteString(strings.Repeat("*", level) + " " + keyword + " ")
+
+	if cookie := priorityCookie(t.Priority); cookie != "" {
+		b.WriteString(cookie + " ")
+	}
+
+	b.WriteString(t.Title)
+
+	if tags := headingTags(t.Tags); tags != "" {
+		b.WriteString(" " + tags)
+	}
+
+	b.WriteString("\n")
+
+	indent := strings.Repeat(" ", level+1)
+	loc := taskLocation(t)
+	rep, rule := repeater(t.RepeatFlag)
+
+	if planning := planningLine(t, loc, rep); planning != "" {
+		b.WriteString(indent + planning + "\n")
+	}
+
+	props := [][2]string{{propertyID, t.ID}, {propertyTimeZone, t.TimeZone}, {propertyRepeat, rule}}
+	writeProperties(b, level, props)
+
+	text := t.Content
+	if len(t.Items) > 0 {
+		text = t.Desc
+	}
+
+	for line := range strings.Lines(strings.TrimRight(text, "\n")) {
+		if line = strings.TrimRight(line, "\n"); line == "" {
+			b.WriteString("\n")
+
+			continue
+		}
+
+		b.WriteString(indent + escapeLine(line) + "\n")
+	}
+
+	for _, item := range t.Items {
+		box := "[ ]"
+		if item.Status == ticktick.ChecklistStatusCompleted {
+			box = "[X]"
+		}
+
+		b.WriteString(indent + "- " + box + " " + item.Title + "\n")
+	}
+}
+
+// escapeLine puts a comma before the text of a line that [Decode] would
+// otherwise read as something other than text.
+func escapeLine(line string) string {
+	text := strings.TrimLeft(line, " \t")
+	if !needsEscape(text) {
+		return line
+	}
+
+	return line[:len(line)-len(text)] + "," + text
+}
+
+// needsEscape reports whether a text line, without its indentation, has to
+// be escaped: it looks like a checkbox item, a planning line or the start of
+// a property drawer, or is such a line already escaped.
+func needsEscape(text string) bool {
+	if rest, ok := strings.CutPrefix(text, ","); ok {
+		return needsEscape(strings.TrimLeft(rest, " \t"))
+	}
+
+	return checkboxPattern.MatchString(text) || planningOnly.MatchString(strings.TrimSpace(text)) ||
+		strings.EqualFold(strings.TrimSpace(text), ":PROPERTIES:")
+}
+
+func writeProperties(b *strings.Builder, level int, props [][2]string) {
+	indent := strings.Repeat(" ", level+1)
+	started := false
+
+	for _, p := range props {
+		if p[1] == "" {
+			continue
+		}
+
+		if !started {
+			b.WriteString(indent + ":PROPERTIES:\n")
+
+			started = true
+		}
+
+		b.WriteString(indent + ":" + p[0] + ": " + p[1] + "\n")
+	}
+
+	if started {
+		b.WriteString(indent + ":END:\n")
+	}
+}
+
+// planningLine returns the CLOSED, DEADLINE and SCHEDULED timestamps of the
+// task. The start date is omitted when it equals the due date.
+func planningLine(t *ticktick.Task, loc *time.Location, rep string) string {
+	var parts []string
+
+	if t.Status == ticktick.TaskStatusCompleted && !t.CompletedTime.IsZero() {
+		parts = append(parts, "CLOSED: ["+t.CompletedTime.In(loc).Format(dateTimeLayout)+"]")
+	}
+
+	hasDue := !t.DueDate.IsZero()
+	hasStart := !t.StartDate.IsZero() && (!hasDue || !t.StartDate.Equal(t.DueDate.Time))
+
+	if hasDue {
+		parts = append(parts, "DEADLINE: "+timestamp(t.DueDate.Time, t.IsAllDay, loc, rep))
+		rep = ""
+	}
+
+	if hasStart {
+		parts = append(parts, "SCHEDULED: "+timestamp(t.StartDate.Time, t.IsAllDay, loc, rep))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func timestamp(t time.Time, allDay bool, loc *time.Location, rep string) string {
+	layout := dateTimeLayout
+	if allDay {
+		layout = dateLayout
+	}
+
+	s := t.In(loc).Format(layout)
+	if rep != "" {
+		s += " " + rep
+	}
+
+	return "<" + s + ">"
+}
+
+// repeater converts a simple repeat rule to an org-mode repeater like "+2w".
+// Rules it cannot express are returned as the second value.
+func repeater(flag string) (string, string) {
+	rule, ok := strings.CutPrefix(flag, "RRULE:")
+	if !ok {
+		return "", flag
+	}
+
+	unit, interval := "", 1
+
+	for part := range strings.SplitSeq(rule, ";") {
+		key, value, _ := strings.Cut(part, "=")
+
+		switch key {
+		case "FREQ":
+			unit = map[string]string{"DAILY": "d", "WEEKLY": "w", "MONTHLY": "m", "YEARLY": "y"}[value]
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return "", flag
+			}
+
+			interval = n
+		default:
+			return "", flag
+		}
+	}
+
+	if unit == "" {
+		return "", flag
+	}
+
+	return "+" + strconv.Itoa(interval) + unit, ""
+}
+
+func priorityCookie(priority int) string {
+	switch priority {
+	case ticktick.PriorityHigh:
+		return "[#A]"
+	case ticktick.PriorityMedium:
+		return "[#B]"
+	case ticktick.PriorityLow:
+		return "[#C]"
+	default:
+		return ""
+	}
+}
+
+// headingTags formats tags as ":a:b:", replacing characters org-mode does not
+// allow in tags with underscores.
+func headingTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	clean := make([]string, len(tags))
+	for i, tag := range tags {
+		clean[i] = invalidTagChars.ReplaceAllString(tag, "_")
+	}
+
+	return ":" + strings.Join(clean, ":") + ":"
+}
+
+func taskLocation(t *ticktick.Task) *time.Location {
+	if t.TimeZone != "" {
+		if loc, err := time.LoadLocation(t.TimeZone); err == nil {
+			return loc
+		}
+	}
+
+	if !t.DueDate.IsZero() {
+		return t.DueDate.Location()
+	}
+
+	return t.StartDate.Location()
+}
@@ -0,0 +1,98 @@
+package org_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/org"
+)
+
+func testProject() *ticktick.ProjectData {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	return &ticktick.ProjectData{
+		Project: ticktick.Project{ID: "p1", Name: "Work"},
+		Tasks: []ticktick.Task{
+			{
+				ID:         "t1",
+				Title:      "Ship release",
+				Kind:       ticktick.TaskKindChecklist,
+				Desc:       "Notes are in the wiki.\n\nSee you there.",
+				StartDate:  ticktick.Time{Time: time.Date(2024, 1, 15, 9, 0, 0, 0, berlin)},
+				DueDate:    ticktick.Time{Time: time.Date(2024, 1, 15, 17, 0, 0, 0, berlin)},
+				TimeZone:   "Europe/Berlin",
+				Priority:   ticktick.PriorityHigh,
+				RepeatFlag: "RRULE:FREQ=WEEKLY;INTERVAL=1",
+				Tags:       []string{"release", "q1 goals"},
+				SortOrder:  1,
+				Items: []ticktick.ChecklistItem{
+					{Title: "Tag the commit", Status: ticktick.ChecklistStatusCompleted},
+					{Title: "Announce"},
+				},
+			},
+			{
+				ID:            "t2",
+				Title:         "Write notes",
+				ParentID:      "t1",
+				Content:       "* not a heading",
+				Status:        ticktick.TaskStatusCompleted,
+				CompletedTime: ticktick.Time{Time: time.Date(2024, 1, 14, 10, 30, 0, 0, time.UTC)},
+				IsAllDay:      true,
+				StartDate:     ticktick.Time{Time: time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+				DueDate:       ticktick.Time{Time: time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+				RepeatFlag:    "RRULE:FREQ=WEEKLY;BYDAY=MO",
+			},
+			{ID: "t3", Title: "Triage", Priority: ticktick.PriorityLow, SortOrder: 2},
+		},
+	}
+}
+
+const testOutline = `* Work
+  :PROPERTIES:
+  :ID: p1
+  :END:
+** TODO [#A] Ship release :release:q1_goals:
+   DEADLINE: <2024-01-15 Mon 17:00 +1w> SCHEDULED: <2024-01-15 Mon 09:00>
+   :PROPERTIES:
+   :ID: t1
+   :TIMEZONE: Europe/Berlin
+   :END:
+   Notes are in the wiki.
+
+   See you there.
+   - [X] Tag the commit
+   - [ ] Announce
+*** DONE Write notes
+    CLOSED: [2024-01-14 Sun 10:30] DEADLINE: <2024-01-14 Sun>
+    :PROPERTIES:
+    :ID: t2
+    :REPEAT: RRULE:FREQ=WEEKLY;BYDAY=MO
+    :END:
+    * not a heading
+** TODO [#C] Triage
+   :PROPERTIES:
+   :ID: t3
+   :END:
+`
+
+func TestEncode(t *testing.T) {
+	var buf bytes.Buffer
+	if err := org.Encode(&buf, testProject()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if buf.String() != testOutline {
+		t.Errorf("expected:\n%s\ngot:\n%s", testOutline, buf.String())
+	}
+}
+
+func TestHeading(t *testing.T) {
+	task := &ticktick.Task{Title: "Call Bob", Content: "Number\nin contacts"}
+
+	want := "* TODO Call Bob\n  Number\n  in contacts\n"
+	if got := org.Heading(task, 1); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
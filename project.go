@@ -43,19 +43,29 @@ func (c *Client) GetProjectData(ctx context.Context, projectID string) (*Project
 	return &data, nil
 }
 
-// CreateProject creates a new project.
+// CreateProject creates a new project. The request is validated before it is sent; see [WithValidation].
 func (c *Client) CreateProject(ctx context.Context, req *CreateProjectRequest) (*Project, error) {
+	if err := c.validateRequest(req); err != nil {
+		return nil, err
+	}
+
 	var project Project
 
 	if err := c.post(ctx, "/open/v1/project", req, &project); err != nil {
 		return nil, err
 	}
 
+	c.notifyChange(Change{ProjectID: project.ID, Created: true})
+
 	return &project, nil
 }
 
-// UpdateProject updates an existing project.
+// UpdateProject updates an existing project. The request is validated before it is sent; see [WithValidation].
 func (c *Client) UpdateProject(ctx context.Context, projectID string, req *UpdateProjectRequest) (*Project, error) {
+	if err := c.validateRequest(req); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("/open/v1/project/%s", url.PathEscape(projectID))
 
 	var project Project
@@ -64,6 +74,8 @@ func (c *Client) UpdateProject(ctx context.Context, projectID string, req *Updat
 		return nil, err
 	}
 
+	c.notifyChange(Change{ProjectID: projectID})
+
 	return &project, nil
 }
 
@@ -71,5 +83,11 @@ func (c *Client) UpdateProject(ctx context.Context, projectID string, req *Updat
 func (c *Client) DeleteProject(ctx context.Context, projectID string) error {
 	path := fmt.Sprintf("/open/v1/project/%s", url.PathEscape(projectID))
 
-	return c.delete(ctx, path)
+	if err := c.delete(ctx, path); err != nil {
+		return err
+	}
+
+	c.notifyChange(Change{ProjectID: projectID})
+
+	return nil
 }
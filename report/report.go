@@ -0,0 +1,228 @@
+// Package report renders TickTick projects as GitHub-flavored Markdown, e.g.
+// for pasting into status updates and documents.
+//
+// [Build] arranges a project's tasks into a [Report], optionally grouped by
+// kanban column or due date, with subtasks and checklist items nested under
+// the task they belong to. [Markdown] renders it; by default as
+//
+//	## Work
+//
+//	### Doing
+//
+//	- [ ] Ship release ![high priority](https://img.shields.io/badge/priority-high-red) 📅 2024-01-15
+//	  - [x] Tag the commit
+//
+// Custom layouts are [text/template] templates created with [NewTemplate],
+// which provides the helpers used by [DefaultTemplate].
+package report
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"text/template"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// Names of the groups collecting tasks without a column or a due date.
+const (
+	NoColumn  = "No column"
+	NoDueDate = "No due date"
+)
+
+const dueTimeLayout = "2006-01-02 15:04"
+
+// GroupBy selects how [Build] groups tasks.
+type GroupBy int
+
+// Grouping modes.
+const (
+	// NoGrouping puts every task in a single unnamed group.
+	NoGrouping GroupBy = iota
+	// ByColumn groups tasks by kanban column, in column order.
+	ByColumn
+	// ByDueDate groups tasks by the calendar date they are due, earliest first.
+	ByDueDate
+)
+
+// Options configures [Build] and [Markdown].
+type Options struct {
+	GroupBy GroupBy
+	// Location is used to show due times. If nil, each task's own time zone is used.
+	// Due dates of all-day tasks are never converted.
+	Location *time.Location
+	// Template renders the report in [Markdown]. If nil, [DefaultTemplate] is used.
+	Template *template.Template
+}
+
+// Report is a project arranged for rendering.
+type Report struct {
+	Project ticktick.Project
+	Groups  []Group
+}
+
+// Group is a named list of entries. Groups without tasks are omitted.
+type Group struct {
+	// Name is the column name, the due date as "2006-01-02", [NoColumn],
+	// [NoDueDate], or empty when tasks are not grouped.
+	Name    string
+	Entries []Entry
+}
+
+// Entry is a task or a checklist item at a nesting depth. Subtasks and
+// checklist items follow the task they belong to, one level deeper.
+type Entry struct {
+	Depth    int
+	Title    string
+	Done     bool
+	Priority int
+	// Due is the formatted due date: "2006-01-02" for all-day tasks and
+	// "2006-01-02 15:04" otherwise. It is empty for checklist items and
+	// tasks without a due date.
+	Due string
+	// Task is the task the entry shows, or the task owning the checklist item.
+	Task *ticktick.Task
+	// Item is the checklist item the entry shows, or nil for a task.
+	Item *ticktick.ChecklistItem
+}
+
+// Build arranges data's tasks into a report.
+func Build(data *ticktick.ProjectData, opts Options) *Report {
+	r := &Report{Project: data.Project}
+
+	for _, g := range groups(data, opts.GroupBy) {
+		if len(g.tasks) == 0 {
+			continue
+		}
+
+		group := Group{Name: g.name}
+
+		for _, root := range ticktick.BuildTaskTree(g.tasks) {
+			root.Walk(func(node *ticktick.TaskNode, depth int) bool {
+				group.Entries = append(group.Entries, entries(&node.Task, depth, opts.Location)...)
+
+				return true
+			})
+		}
+
+		r.Groups = append(r.Groups, group)
+	}
+
+	return r
+}
+
+// Markdown renders data as GitHub-flavored Markdown to w using opts.Template,
+// or [DefaultTemplate] if it is nil. The template is executed with a *[Report].
+func Markdown(w io.Writer, data *ticktick.ProjectData, opts Options) error {
+	tmpl := opts.Template
+	if tmpl == nil {
+		tmpl = defaultTemplate()
+	}
+
+	if err := tmpl.Execute(w, Build(data, opts)); err != nil {
+		return fmt.Errorf("report: render markdown: %w", err)
+	}
+
+	return nil
+}
+
+type taskGroup struct {
+	name  string
+	tasks []ticktick.Task
+}
+
+func groups(data *ticktick.ProjectData, by GroupBy) []taskGroup {
+	switch by {
+	case ByColumn:
+		var result []taskGroup
+
+		for _, ct := range data.TasksByColumn() {
+			name := ct.Column.Name
+			if ct.Column.ID == "" {
+				name = NoColumn
+			}
+
+			result = append(result, taskGroup{name: name, tasks: ct.Tasks})
+		}
+
+		return result
+	case ByDueDate:
+		return dueDateGroups(data.Tasks)
+	case NoGrouping:
+	}
+
+	return []taskGroup{{tasks: data.Tasks}}
+}
+
+func dueDateGroups(tasks []ticktick.Task) []taskGroup {
+	byDay := make(map[ticktick.Date][]ticktick.Task)
+
+	for _, t := range tasks {
+		day := t.DueDay()
+		byDay[day] = append(byDay[day], t)
+	}
+
+	days := slices.DeleteFunc(slices.Collect(maps.Keys(byDay)), ticktick.Date.IsZero)
+	slices.SortFunc(days, func(a, b ticktick.Date) int {
+		return a.In(time.UTC).Compare(b.In(time.UTC))
+	})
+
+	result := make([]taskGroup, 0, len(days)+1)
+	for _, day := range days {
+		result = append(result, taskGroup{name: day.String(), tasks: byDay[day]})
+	}
+
+	return append(result, taskGroup{name: NoDueDate, tasks: byDay[ticktick.Date{}]})
+}
+
+func entries(t *ticktick.Task, depth int, loc *time.Location) []Entry {
+	result := []Entry{{
+		Depth:    depth,
+		Title:    t.Title,
+		Done:     t.Status == ticktick.TaskStatusCompleted,
+		Priority: t.Priority,
+		Due:      dueString(t, loc),
+		Task:     t,
+	}}
+
+	items := slices.Clone(t.Items)
+	slices.SortStableFunc(items, func(a, b ticktick.ChecklistItem) int {
+		return cmp.Compare(a.SortOrder, b.SortOrder)
+	})
+
+	for i := range items {
+		result = append(result, Entry{
+			Depth: depth + 1,
+			Title: items[i].Title,
+			Done:  items[i].Status == ticktick.ChecklistStatusCompleted,
+			Task:  t,
+			Item:  &items[i],
+		})
+	}
+
+	return result
+}
+
+func dueString(t *ticktick.Task, loc *time.Location) string {
+	if t.DueDate.IsZero() {
+		return ""
+	}
+
+	if t.IsAllDay {
+		return t.DueDay().String()
+	}
+
+	if loc == nil {
+		loc = t.DueDate.Location()
+
+		if zone, err := time.LoadLocation(t.TimeZone); t.TimeZone != "" && err == nil {
+			loc = zone
+		}
+	}
+
+	return t.DueDate.In(loc).Format(dueTimeLayout)
+}
@@ -0,0 +1,116 @@
+package report_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/report"
+)
+
+func testData() *ticktick.ProjectData {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	return &ticktick.ProjectData{
+		Project: ticktick.Project{ID: "p1", Name: "Work"},
+		Columns: []ticktick.Column{
+			{ID: "c2", Name: "Done", SortOrder: 2},
+			{ID: "c1", Name: "Doing", SortOrder: 1},
+			{ID: "c3", Name: "Empty", SortOrder: 3},
+		},
+		Tasks: []ticktick.Task{
+			{
+				ID:       "t1",
+				Title:    "Ship release",
+				ColumnID: "c1",
+				Priority: ticktick.PriorityHigh,
+				IsAllDay: true,
+				DueDate:  ticktick.Time{Time: time.Date(2024, 1, 14, 23, 0, 0, 0, time.UTC)},
+				TimeZone: "Europe/Berlin",
+				Items: []ticktick.ChecklistItem{
+					{Title: "Announce", SortOrder: 2},
+					{Title: "Tag", Status: ticktick.ChecklistStatusCompleted, SortOrder: 1},
+				},
+			},
+			{
+				ID:       "t2",
+				Title:    "Write notes",
+				ColumnID: "c1",
+				ParentID: "t1",
+				DueDate:  ticktick.Time{Time: time.Date(2024, 1, 15, 9, 30, 0, 0, berlin)},
+			},
+			{ID: "t3", Title: "Fix bug", ColumnID: "c2", Status: ticktick.TaskStatusCompleted, SortOrder: 1},
+			{ID: "t4", Title: "Triage", Priority: ticktick.PriorityLow, SortOrder: 2},
+		},
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := report.Markdown(&buf, testData(), report.Options{GroupBy: report.ByColumn}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "## Work\n" +
+		"\n### Doing\n\n" +
+		"- [ ] Ship release ![high priority](https://img.shields.io/badge/priority-high-red) 📅 2024-01-15\n" +
+		"  - [x] Tag\n" +
+		"  - [ ] Announce\n" +
+		"  - [ ] Write notes 📅 2024-01-15 09:30\n" +
+		"\n### Done\n\n" +
+		"- [x] Fix bug\n" +
+		"\n### No column\n\n" +
+		"- [ ] Triage ![low priority](https://img.shields.io/badge/priority-low-blue)\n"
+
+	if buf.String() != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestBuildByDueDate(t *testing.T) {
+	r := report.Build(testData(), report.Options{GroupBy: report.ByDueDate, Location: time.UTC})
+
+	if len(r.Groups) != 2 || r.Groups[0].Name != "2024-01-15" || r.Groups[1].Name != report.NoDueDate {
+		t.Fatalf("unexpected groups: %+v", r.Groups)
+	}
+
+	entries := r.Groups[0].Entries
+	if len(entries) != 4 || entries[3].Title != "Write notes" || entries[3].Depth != 1 {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+
+	if entries[3].Due != "2024-01-15 08:30" {
+		t.Errorf("expected the due time in UTC, got %s", entries[3].Due)
+	}
+
+	if entries[1].Item == nil || entries[1].Task.ID != "t1" {
+		t.Errorf("expected a checklist item of t1, got %+v", entries[1])
+	}
+}
+
+func TestBuildNoGrouping(t *testing.T) {
+	r := report.Build(testData(), report.Options{})
+
+	if len(r.Groups) != 1 || r.Groups[0].Name != "" {
+		t.Fatalf("unexpected groups: %+v", r.Groups)
+	}
+
+	var titles []string
+	for _, e := range r.Groups[0].Entries {
+		titles = append(titles, e.Title)
+	}
+
+	want := []string{"Ship release", "Tag", "Announce", "Write notes", "Fix bug", "Triage"}
+	if len(titles) != len(want) {
+		t.Fatalf("expected %v, got %v", want, titles)
+	}
+
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, titles)
+
+			break
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// DefaultTemplate is the layout [Markdown] uses when no template is set: the
+// project name as a heading, a subheading per group and a checkbox list item
+// per entry, indented by depth, with a priority badge and the due date.
+const DefaultTemplate = `## {{.Project.Name}}
+{{range .Groups}}{{with .Name}}
+### {{.}}
+{{end}}
+{{range .Entries}}{{indent .Depth}}- {{checkbox .Done}} {{.Title}}{{with badge .Priority}} {{.}}{{end}}` +
+	`{{with .Due}} 📅 {{.}}{{end}}
+{{end}}{{end}}`
+
+// Badge colors, as understood by shields.io.
+const (
+	highColor   = "red"
+	mediumColor = "yellow"
+	lowColor    = "blue"
+)
+
+// NewTemplate parses text as a report template. In addition to the standard
+// [text/template] functions, templates can call:
+//
+//	checkbox DONE     "[x]" or "[ ]"
+//	indent DEPTH      two spaces per nesting level
+//	priority P        "high", "medium", "low", or "" for no priority
+//	badge P           a shields.io image of the priority, or ""
+//
+// The template is executed with a *[Report].
+func NewTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"checkbox": Checkbox,
+		"indent":   indent,
+		"priority": PriorityName,
+		"badge":    Badge,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("report: parse template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// Checkbox returns the GitHub-flavored Markdown task list marker for done.
+func Checkbox(done bool) string {
+	if done {
+		return "[x]"
+	}
+
+	return "[ ]"
+}
+
+// PriorityName returns "high", "medium" or "low" for the TickTick priorities,
+// and an empty string for [ticktick.PriorityNone] or an unknown value.
+func PriorityName(priority int) string {
+	switch priority {
+	case ticktick.PriorityHigh:
+		return "high"
+	case ticktick.PriorityMedium:
+		return "medium"
+	case ticktick.PriorityLow:
+		return "low"
+	default:
+		return ""
+	}
+}
+
+// Badge returns a Markdown image of a shields.io priority badge, or an empty
+// string if the task has no priority.
+func Badge(priority int) string {
+	name := PriorityName(priority)
+
+	var color string
+
+	switch priority {
+	case ticktick.PriorityHigh:
+		color = highColor
+	case ticktick.PriorityMedium:
+		color = mediumColor
+	case ticktick.PriorityLow:
+		color = lowColor
+	default:
+		return ""
+	}
+
+	return fmt.Sprintf("![%s priority](https://img.shields.io/badge/priority-%s-%s)", name, name, color)
+}
+
+func indent(depth int) string {
+	return strings.Repeat("  ", depth)
+}
+
+func defaultTemplate() *template.Template {
+	return template.Must(NewTemplate(DefaultTemplate))
+}
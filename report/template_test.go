@@ -0,0 +1,59 @@
+package report_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/report"
+)
+
+func TestNewTemplate(t *testing.T) {
+	tmpl, err := report.NewTemplate(`{{range .Groups}}{{range .Entries}}{{if not .Item}}` +
+		`{{checkbox .Done}} {{.Title}} ({{or (priority .Priority) "none"}})
+{{end}}{{end}}{{end}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := report.Markdown(&buf, testData(), report.Options{Template: tmpl}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "[ ] Ship release (high)\n[ ] Write notes (none)\n[x] Fix bug (none)\n[ ] Triage (low)\n"
+	if buf.String() != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestNewTemplateError(t *testing.T) {
+	if _, err := report.NewTemplate("{{.Groups"); err == nil {
+		t.Error("expected a parse error")
+	}
+
+	tmpl, _ := report.NewTemplate("{{.Missing}}")
+
+	var buf bytes.Buffer
+	if err := report.Markdown(&buf, testData(), report.Options{Template: tmpl}); err == nil {
+		t.Error("expected an execution error")
+	}
+}
+
+func TestBadge(t *testing.T) {
+	tests := []struct {
+		priority int
+		want     string
+	}{
+		{ticktick.PriorityHigh, "![high priority](https://img.shields.io/badge/priority-high-red)"},
+		{ticktick.PriorityMedium, "![medium priority](https://img.shields.io/badge/priority-medium-yellow)"},
+		{ticktick.PriorityLow, "![low priority](https://img.shields.io/badge/priority-low-blue)"},
+		{ticktick.PriorityNone, ""},
+	}
+
+	for _, tt := range tests {
+		if got := report.Badge(tt.priority); got != tt.want {
+			t.Errorf("priority %d: expected %q, got %q", tt.priority, tt.want, got)
+		}
+	}
+}
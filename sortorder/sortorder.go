@@ -0,0 +1,205 @@
+// Package sortorder computes SortOrder values for placing TickTick tasks and
+// projects relative to each other.
+//
+// TickTick orders items by an int64 SortOrder and spaces neighbors [Step] apart,
+// leaving room to insert between them without touching other items. When
+// repeated insertions exhaust a gap, the list is rebalanced and only the items
+// whose value actually changed are reported, so that applying the result
+// costs as few API calls as possible.
+package sortorder
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// Step is the spacing TickTick uses between neighboring sort orders.
+const Step int64 = 1 << 40
+
+// ErrNotFound is returned by [Move] when the item to move is not in the list.
+var ErrNotFound = errors.New("sortorder: item not found")
+
+// Item is an element of an ordered list.
+type Item struct {
+	ID        string
+	SortOrder int64
+}
+
+// Update is a new sort order for one item.
+type Update struct {
+	ID        string
+	SortOrder int64
+}
+
+// TaskItems returns the tasks as items ordered by SortOrder.
+func TaskItems(tasks []ticktick.Task) []Item {
+	items := make([]Item, len(tasks))
+	for i, t := range tasks {
+		items[i] = Item{ID: t.ID, SortOrder: t.SortOrder}
+	}
+
+	return sorted(items)
+}
+
+// ProjectItems returns the projects as items ordered by SortOrder.
+func ProjectItems(projects []ticktick.Project) []Item {
+	items := make([]Item, len(projects))
+	for i, p := range projects {
+		items[i] = Item{ID: p.ID, SortOrder: p.SortOrder}
+	}
+
+	return sorted(items)
+}
+
+// Between returns a sort order strictly between before and after. It reports
+// false if the two values are adjacent or out of order.
+func Between(before, after int64) (int64, bool) {
+	// A value strictly between the two needs a gap of at least two.
+	if after-before <= 1 {
+		return 0, false
+	}
+
+	return before + (after-before)/2, true
+}
+
+// Top returns a sort order that places a new item above every item in list.
+func Top(list []Item) int64 {
+	if len(list) == 0 {
+		return 0
+	}
+
+	return slices.MinFunc(list, compareItems).SortOrder - Step
+}
+
+// Bottom returns a sort order that places a new item below every item in list.
+func Bottom(list []Item) int64 {
+	if len(list) == 0 {
+		return 0
+	}
+
+	return slices.MaxFunc(list, compareItems).SortOrder + Step
+}
+
+// Insert returns the sort order for a new item placed at position index of
+// list, where index 0 is the top and len(list) the bottom. If there is no room
+// at that position, the list is rebalanced and the returned updates hold the
+// new sort orders of the existing items that had to change; otherwise updates
+// is empty. The index is clamped to the bounds of list.
+func Insert(list []Item, index int) (int64, []Update) {
+	list = sorted(list)
+	index = max(0, min(index, len(list)))
+
+	switch {
+	case len(list) == 0:
+		return 0, nil
+	case index == 0:
+		return Top(list), nil
+	case index == len(list):
+		return Bottom(list), nil
+	}
+
+	if order, ok := Between(list[index-1].SortOrder, list[index].SortOrder); ok {
+		return order, nil
+	}
+
+	// No gap left: respace the whole list around the new item, keeping the
+	// first item's value so that as few items as possible change.
+	start := list[0].SortOrder
+
+	var updates []Update
+
+	for i, it := range list {
+		pos := i
+		if i >= index {
+			pos++
+		}
+
+		if order := start + int64(pos)*Step; order != it.SortOrder {
+			updates = append(updates, Update{ID: it.ID, SortOrder: order})
+		}
+	}
+
+	return start + int64(index)*Step, updates
+}
+
+// Move returns the updates that place the item with the given ID at position
+// index of list, counted after the item has been removed from its current
+// position. The moved item's update is always included.
+func Move(list []Item, id string, index int) ([]Update, error) {
+	list = sorted(list)
+
+	pos := slices.IndexFunc(list, func(it Item) bool { return it.ID == id })
+	if pos < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	rest := slices.Delete(slices.Clone(list), pos, pos+1)
+	order, updates := Insert(rest, index)
+
+	return append(updates, Update{ID: id, SortOrder: order}), nil
+}
+
+// Rebalance spaces the items of list [Step] apart, keeping their current order
+// and the first item's value, and returns updates for the items that changed.
+func Rebalance(list []Item) []Update {
+	list = sorted(list)
+	if len(list) == 0 {
+		return nil
+	}
+
+	var updates []Update
+
+	for i, it := range list {
+		if order := list[0].SortOrder + int64(i)*Step; order != it.SortOrder {
+			updates = append(updates, Update{ID: it.ID, SortOrder: order})
+		}
+	}
+
+	return updates
+}
+
+// ApplyTasks sends the updates as UpdateTask calls for tasks of the given project.
+func ApplyTasks(ctx context.Context, client *ticktick.Client, projectID string, updates []Update) error {
+	for _, u := range updates {
+		_, err := client.UpdateTask(ctx, u.ID, &ticktick.UpdateTaskRequest{
+			ID:        u.ID,
+			ProjectID: projectID,
+			SortOrder: ticktick.Int64(u.SortOrder),
+		})
+		if err != nil {
+			return fmt.Errorf("sortorder: update task %s: %w", u.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// ApplyProjects sends the updates as UpdateProject calls.
+func ApplyProjects(ctx context.Context, client *ticktick.Client, updates []Update) error {
+	for _, u := range updates {
+		_, err := client.UpdateProject(ctx, u.ID, &ticktick.UpdateProjectRequest{
+			SortOrder: ticktick.Int64(u.SortOrder),
+		})
+		if err != nil {
+			return fmt.Errorf("sortorder: update project %s: %w", u.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func sorted(list []Item) []Item {
+	list = slices.Clone(list)
+	slices.SortStableFunc(list, compareItems)
+
+	return list
+}
+
+func compareItems(a, b Item) int {
+	return cmp.Compare(a.SortOrder, b.SortOrder)
+}
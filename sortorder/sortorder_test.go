@@ -0,0 +1,191 @@
+package sortorder_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/sortorder"
+)
+
+func TestBetween(t *testing.T) {
+	if got, ok := sortorder.Between(0, sortorder.Step); !ok || got != sortorder.Step/2 {
+		t.Errorf("expected %d, got %d (ok=%v)", sortorder.Step/2, got, ok)
+	}
+
+	if _, ok := sortorder.Between(10, 11); ok {
+		t.Error("expected no room between adjacent values")
+	}
+
+	if _, ok := sortorder.Between(10, 5); ok {
+		t.Error("expected no room for reversed values")
+	}
+}
+
+func TestTopBottom(t *testing.T) {
+	list := []sortorder.Item{{ID: "b", SortOrder: 5}, {ID: "a", SortOrder: -3}}
+
+	if got := sortorder.Top(list); got != -3-sortorder.Step {
+		t.Errorf("unexpected top: %d", got)
+	}
+
+	if got := sortorder.Bottom(list); got != 5+sortorder.Step {
+		t.Errorf("unexpected bottom: %d", got)
+	}
+
+	if sortorder.Top(nil) != 0 || sortorder.Bottom(nil) != 0 {
+		t.Error("expected 0 for empty list")
+	}
+}
+
+func TestInsertWithGap(t *testing.T) {
+	list := []sortorder.Item{{ID: "b", SortOrder: sortorder.Step}, {ID: "a", SortOrder: 0}}
+
+	order, updates := sortorder.Insert(list, 1)
+	if order != sortorder.Step/2 || len(updates) != 0 {
+		t.Errorf("expected %d with no updates, got %d and %v", sortorder.Step/2, order, updates)
+	}
+
+	if order, _ := sortorder.Insert(list, -5); order != -sortorder.Step {
+		t.Errorf("expected clamped top insert, got %d", order)
+	}
+
+	if order, _ := sortorder.Insert(list, 99); order != 2*sortorder.Step {
+		t.Errorf("expected clamped bottom insert, got %d", order)
+	}
+
+	if order, updates := sortorder.Insert(nil, 0); order != 0 || updates != nil {
+		t.Errorf("unexpected insert into empty list: %d %v", order, updates)
+	}
+}
+
+func TestInsertRebalances(t *testing.T) {
+	list := []sortorder.Item{
+		{ID: "a", SortOrder: 100},
+		{ID: "b", SortOrder: 101},
+		{ID: "c", SortOrder: 100 + 2*sortorder.Step},
+	}
+
+	order, updates := sortorder.Insert(list, 1)
+
+	if order != 100+sortorder.Step {
+		t.Errorf("expected new order %d, got %d", 100+sortorder.Step, order)
+	}
+
+	// "a" keeps its value; "b" and "c" shift down to make room.
+	want := map[string]int64{"b": 100 + 2*sortorder.Step, "c": 100 + 3*sortorder.Step}
+	if len(updates) != len(want) {
+		t.Fatalf("expected %d updates, got %v", len(want), updates)
+	}
+
+	for _, u := range updates {
+		if want[u.ID] != u.SortOrder {
+			t.Errorf("unexpected update %+v", u)
+		}
+	}
+}
+
+func TestMove(t *testing.T) {
+	list := []sortorder.Item{
+		{ID: "a", SortOrder: 0},
+		{ID: "b", SortOrder: sortorder.Step},
+		{ID: "c", SortOrder: 2 * sortorder.Step},
+	}
+
+	updates, err := sortorder.Move(list, "c", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(updates) != 1 || updates[0].ID != "c" || updates[0].SortOrder != -sortorder.Step {
+		t.Errorf("unexpected updates: %v", updates)
+	}
+
+	if _, err := sortorder.Move(list, "missing", 0); !errors.Is(err, sortorder.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRebalance(t *testing.T) {
+	list := []sortorder.Item{
+		{ID: "a", SortOrder: 7},
+		{ID: "b", SortOrder: 7 + sortorder.Step},
+		{ID: "c", SortOrder: 8 + sortorder.Step},
+	}
+
+	updates := sortorder.Rebalance(list)
+	if len(updates) != 1 || updates[0].ID != "c" || updates[0].SortOrder != 7+2*sortorder.Step {
+		t.Errorf("unexpected updates: %v", updates)
+	}
+
+	if sortorder.Rebalance(nil) != nil {
+		t.Error("expected nil for empty list")
+	}
+}
+
+func TestItems(t *testing.T) {
+	tasks := sortorder.TaskItems([]ticktick.Task{{ID: "t2", SortOrder: 2}, {ID: "t1", SortOrder: 1}})
+	if tasks[0].ID != "t1" || tasks[1].ID != "t2" {
+		t.Errorf("unexpected task items: %v", tasks)
+	}
+
+	projects := sortorder.ProjectItems([]ticktick.Project{{ID: "p2", SortOrder: 9}, {ID: "p1", SortOrder: -1}})
+	if projects[0].ID != "p1" || projects[1].ID != "p2" {
+		t.Errorf("unexpected project items: %v", projects)
+	}
+}
+
+func TestApplyTasks(t *testing.T) {
+	var got []ticktick.UpdateTaskRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ticktick.UpdateTaskRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		if r.URL.Path != "/open/v1/task/"+req.ID {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		got = append(got, req)
+
+		json.NewEncoder(w).Encode(ticktick.Task{ID: req.ID})
+	}))
+	defer server.Close()
+
+	client := ticktick.NewClient("token", ticktick.WithBaseURL(server.URL))
+
+	err := sortorder.ApplyTasks(context.Background(), client, "proj1", []sortorder.Update{
+		{ID: "t1", SortOrder: 10},
+		{ID: "t2", SortOrder: 20},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 2 || got[1].ProjectID != "proj1" || got[1].SortOrder == nil || *got[1].SortOrder != 20 {
+		t.Errorf("unexpected requests: %+v", got)
+	}
+}
+
+func TestApplyProjectsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := ticktick.NewClient("token", ticktick.WithBaseURL(server.URL))
+
+	err := sortorder.ApplyProjects(context.Background(), client, []sortorder.Update{{ID: "p1", SortOrder: 1}})
+
+	var apiErr *ticktick.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *ticktick.Error, got %v", err)
+	}
+}
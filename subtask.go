@@ -0,0 +1,170 @@
+package ticktick
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// TaskNode is a task together with its nested subtasks.
+type TaskNode struct {
+	Task     Task
+	Children []*TaskNode
+}
+
+// BuildTaskTree arranges tasks into a hierarchy using their ParentID. Tasks
+// whose parent is not among tasks become roots. Roots are ordered by SortOrder;
+// children follow the parent's ChildIDs order, with any children not listed
+// there appended by SortOrder.
+func BuildTaskTree(tasks []Task) []*TaskNode {
+	nodes := make(map[string]*TaskNode, len(tasks))
+	for _, t := range tasks {
+		nodes[t.ID] = &TaskNode{Task: t}
+	}
+
+	var roots []*TaskNode
+
+	for _, t := range sortedTasks(tasks) {
+		node := nodes[t.ID]
+
+		parent, ok := nodes[t.ParentID]
+		if !ok || t.ParentID == t.ID || isDescendant(node, parent) {
+			roots = append(roots, node)
+
+			continue
+		}
+
+		parent.Children = append(parent.Children, node)
+	}
+
+	for _, node := range nodes {
+		orderChildren(node)
+	}
+
+	return roots
+}
+
+// TaskTree arranges the project's tasks into a hierarchy. See [BuildTaskTree].
+func (d *ProjectData) TaskTree() []*TaskNode {
+	return BuildTaskTree(d.Tasks)
+}
+
+// Walk calls fn for n and each of its descendants in depth-first order, passing
+// the depth relative to n (0 for n itself). If fn returns false, the node's
+// children are skipped.
+func (n *TaskNode) Walk(fn func(node *TaskNode, depth int) bool) {
+	n.walk(fn, 0)
+}
+
+// WalkTasks calls fn for each of the trees in roots and their descendants in
+// depth-first order, like [TaskNode.Walk], and stops at the first error fn
+// returns, which it returns.
+func WalkTasks(roots []*TaskNode, fn func(node *TaskNode, depth int) error) error {
+	var err error
+
+	for _, root := range roots {
+		root.Walk(func(node *TaskNode, depth int) bool {
+			if err == nil {
+				err = fn(node, depth)
+			}
+
+			return err == nil
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Descendants returns every task nested under n, in depth-first order.
+func (n *TaskNode) Descendants() []Task {
+	var tasks []Task
+
+	n.Walk(func(node *TaskNode, depth int) bool {
+		if depth > 0 {
+			tasks = append(tasks, node.Task)
+		}
+
+		return true
+	})
+
+	return tasks
+}
+
+// CreateSubtask creates a task nested under the task parentID. The parent must
+// belong to req.ProjectID. The request is not modified.
+func (c *Client) CreateSubtask(ctx context.Context, parentID string, req *CreateTaskRequest) (*Task, error) {
+	if req == nil {
+		return c.CreateTask(ctx, nil)
+	}
+
+	sub := *req
+	sub.ParentID = String(parentID)
+
+	return c.CreateTask(ctx, &sub)
+}
+
+func (n *TaskNode) walk(fn func(node *TaskNode, depth int) bool, depth int) {
+	if !fn(n, depth) {
+		return
+	}
+
+	for _, child := range n.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// isDescendant reports whether candidate is node or nested under it, which
+// would make attaching node under candidate a cycle.
+func isDescendant(node, candidate *TaskNode) bool {
+	found := false
+
+	node.Walk(func(n *TaskNode, _ int) bool {
+		if n == candidate {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func orderChildren(node *TaskNode) {
+	if len(node.Children) <= 1 {
+		return
+	}
+
+	position := make(map[string]int, len(node.Task.ChildIDs))
+	for i, id := range node.Task.ChildIDs {
+		position[id] = i
+	}
+
+	slices.SortStableFunc(node.Children, func(a, b *TaskNode) int {
+		pa, aListed := position[a.Task.ID]
+		pb, bListed := position[b.Task.ID]
+
+		switch {
+		case aListed && bListed:
+			return cmp.Compare(pa, pb)
+		case aListed:
+			return -1
+		case bListed:
+			return 1
+		default:
+			return cmp.Compare(a.Task.SortOrder, b.Task.SortOrder)
+		}
+	})
+}
+
+func sortedTasks(tasks []Task) []Task {
+	tasks = slices.Clone(tasks)
+	slices.SortStableFunc(tasks, func(a, b Task) int {
+		return cmp.Compare(a.SortOrder, b.SortOrder)
+	})
+
+	return tasks
+}
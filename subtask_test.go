@@ -0,0 +1,158 @@
+package ticktick_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+func testTaskHierarchy() []ticktick.Task {
+	return []ticktick.Task{
+		{ID: "root", Title: "Root", SortOrder: 1, ChildIDs: []string{"b", "a"}},
+		{ID: "a", Title: "A", ParentID: "root", SortOrder: 1},
+		{ID: "b", Title: "B", ParentID: "root", SortOrder: 2},
+		{ID: "c", Title: "C", ParentID: "root", SortOrder: 0},
+		{ID: "a1", Title: "A1", ParentID: "a"},
+		{ID: "orphan", Title: "Orphan", ParentID: "gone", SortOrder: 2},
+	}
+}
+
+func outline(roots []*ticktick.TaskNode) string {
+	var b strings.Builder
+
+	for _, root := range roots {
+		root.Walk(func(n *ticktick.TaskNode, depth int) bool {
+			b.WriteString(strings.Repeat("  ", depth) + n.Task.Title + "\n")
+
+			return true
+		})
+	}
+
+	return b.String()
+}
+
+func TestBuildTaskTree(t *testing.T) {
+	roots := ticktick.BuildTaskTree(testTaskHierarchy())
+
+	// Listed children follow ChildIDs; unlisted ones are appended by SortOrder.
+	expected := "Root\n  B\n  A\n    A1\n  C\nOrphan\n"
+	if got := outline(roots); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestBuildTaskTreeCycle(t *testing.T) {
+	roots := ticktick.BuildTaskTree([]ticktick.Task{
+		{ID: "a", Title: "A", ParentID: "b", SortOrder: 1},
+		{ID: "b", Title: "B", ParentID: "a", SortOrder: 2},
+		{ID: "self", Title: "Self", ParentID: "self", SortOrder: 3},
+	})
+
+	expected := "B\n  A\nSelf\n"
+	if got := outline(roots); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestTaskNodeDescendants(t *testing.T) {
+	data := ticktick.ProjectData{Tasks: testTaskHierarchy()}
+	roots := data.TaskTree()
+
+	var ids []string
+	for _, task := range roots[0].Descendants() {
+		ids = append(ids, task.ID)
+	}
+
+	if strings.Join(ids, ",") != "b,a,a1,c" {
+		t.Errorf("unexpected descendants: %v", ids)
+	}
+}
+
+func TestTaskNodeWalkSkipsChildren(t *testing.T) {
+	roots := ticktick.BuildTaskTree(testTaskHierarchy())
+
+	var visited []string
+
+	roots[0].Walk(func(n *ticktick.TaskNode, _ int) bool {
+		visited = append(visited, n.Task.ID)
+
+		return n.Task.ID != "a"
+	})
+
+	if strings.Join(visited, ",") != "root,b,a,c" {
+		t.Errorf("unexpected visit order: %v", visited)
+	}
+}
+
+func TestWalkTasksStopsAtError(t *testing.T) {
+	roots := ticktick.BuildTaskTree(testTaskHierarchy())
+	errStop := errors.New("stop")
+
+	var visited []string
+
+	err := ticktick.WalkTasks(roots, func(n *ticktick.TaskNode, _ int) error {
+		visited = append(visited, n.Task.ID)
+
+		if n.Task.ID == "b" {
+			return errStop
+		}
+
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("expected the callback's error, got %v", err)
+	}
+
+	if strings.Join(visited, ",") != "root,b" {
+		t.Errorf("expected the walk to stop at the error, visited %v", visited)
+	}
+}
+
+func TestCreateSubtask(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open/v1/task" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var req ticktick.CreateTaskRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		if req.ParentID == nil || *req.ParentID != "parent1" {
+			t.Errorf("expected parentId parent1, got %v", req.ParentID)
+		}
+
+		json.NewEncoder(w).Encode(ticktick.Task{ID: "child1", ProjectID: "proj1", ParentID: "parent1"})
+	})
+	defer server.Close()
+
+	req := &ticktick.CreateTaskRequest{Title: "Child", ProjectID: "proj1"}
+
+	task, err := client.CreateSubtask(context.Background(), "parent1", req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.ParentID != "parent1" {
+		t.Errorf("expected parent parent1, got %s", task.ParentID)
+	}
+
+	if req.ParentID != nil {
+		t.Error("expected caller's request to be left unmodified")
+	}
+}
+
+func TestCreateSubtaskNilRequest(t *testing.T) {
+	client := ticktick.NewClient("token")
+
+	if _, err := client.CreateSubtask(context.Background(), "parent1", nil); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
@@ -0,0 +1,98 @@
+package ticktick
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// hashtagPattern matches a #hashtag at the start of the text or after whitespace.
+var hashtagPattern = regexp.MustCompile(`(?:^|\s)#([\p{L}\p{N}_/-]+)`)
+
+// TagCount is a tag together with the number of tasks that carry it.
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// ParseHashtags returns the #hashtags in s, lowercased and without duplicates,
+// in order of first appearance. A '#' inside a word, as in "C#", is not a tag.
+func ParseHashtags(s string) []string {
+	var tags []string
+
+	for _, m := range hashtagPattern.FindAllStringSubmatch(s, -1) {
+		tag := strings.ToLower(m[1])
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// EffectiveTags returns the task's tags. When the Tags field is empty, the
+// #hashtags in the title and then the content are used instead, since TickTick
+// clients turn those into tags. Tags are lowercased because TickTick treats
+// them case-insensitively.
+func (t *Task) EffectiveTags() []string {
+	if len(t.Tags) == 0 {
+		return ParseHashtags(t.Title + "\n" + t.Content)
+	}
+
+	tags := make([]string, 0, len(t.Tags))
+	for _, tag := range t.Tags {
+		tag = strings.ToLower(tag)
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// HasTag reports whether the task carries the given tag, compared case-insensitively.
+func (t *Task) HasTag(tag string) bool {
+	return slices.Contains(t.EffectiveTags(), strings.ToLower(tag))
+}
+
+// CountTags counts how many tasks carry each tag, using [Task.EffectiveTags].
+// The result is ordered by descending count, then by tag.
+func CountTags(tasks []Task) []TagCount {
+	counts := make(map[string]int)
+
+	for i := range tasks {
+		for _, tag := range tasks[i].EffectiveTags() {
+			counts[tag]++
+		}
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for tag, n := range counts {
+		result = append(result, TagCount{Tag: tag, Count: n})
+	}
+
+	slices.SortFunc(result, func(a, b TagCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Tag, b.Tag)
+	})
+
+	return result
+}
+
+// TasksByTag groups tasks by each tag they carry, using [Task.EffectiveTags].
+// A task with several tags appears under each of them; untagged tasks are omitted.
+func TasksByTag(tasks []Task) map[string][]Task {
+	byTag := make(map[string][]Task)
+
+	for i := range tasks {
+		for _, tag := range tasks[i].EffectiveTags() {
+			byTag[tag] = append(byTag[tag], tasks[i])
+		}
+	}
+
+	return byTag
+}
@@ -0,0 +1,98 @@
+package ticktick_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+func TestParseHashtags(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Buy milk #errands #Home", "errands,home"},
+		{"#work review PR #work", "work"},
+		{"Learn C# today", ""},
+		{"Plan #q3/okr and #follow-up", "q3/okr,follow-up"},
+		{"Ünïcode #café", "café"},
+	}
+
+	for _, tt := range tests {
+		got := strings.Join(ticktick.ParseHashtags(tt.in), ",")
+		if got != tt.want {
+			t.Errorf("ParseHashtags(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTaskEffectiveTags(t *testing.T) {
+	tagged := ticktick.Task{Title: "Call #mom", Tags: []string{"Family", "family", "phone"}}
+	if got := strings.Join(tagged.EffectiveTags(), ","); got != "family,phone" {
+		t.Errorf("expected tags field to win, got %q", got)
+	}
+
+	untagged := ticktick.Task{Title: "Call #mom"}
+	if got := strings.Join(untagged.EffectiveTags(), ","); got != "mom" {
+		t.Errorf("expected hashtag fallback, got %q", got)
+	}
+
+	withContent := ticktick.Task{Title: "Call #mom", Content: "About #Holidays\n# Notes\nand #mom"}
+	if got := strings.Join(withContent.EffectiveTags(), ","); got != "mom,holidays" {
+		t.Errorf("expected hashtags from the content, got %q", got)
+	}
+
+	if !tagged.HasTag("FAMILY") || tagged.HasTag("mom") {
+		t.Error("unexpected HasTag result")
+	}
+}
+
+func TestTaskTagsJSON(t *testing.T) {
+	var task ticktick.Task
+
+	if err := json.Unmarshal([]byte(`{"id":"t1","tags":["work","urgent"]}`), &task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(task.Tags) != 2 || task.Tags[1] != "urgent" {
+		t.Errorf("unexpected tags: %v", task.Tags)
+	}
+
+	data, err := json.Marshal(ticktick.CreateTaskRequest{Title: "T", ProjectID: "p", Tags: []string{"work"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"tags":["work"]`) {
+		t.Errorf("expected tags in request, got %s", data)
+	}
+}
+
+func TestCountTags(t *testing.T) {
+	tasks := []ticktick.Task{
+		{ID: "t1", Tags: []string{"work", "urgent"}},
+		{ID: "t2", Tags: []string{"work"}},
+		{ID: "t3", Title: "Groceries #errands"},
+		{ID: "t4", Title: "No tags"},
+	}
+
+	counts := ticktick.CountTags(tasks)
+
+	want := []ticktick.TagCount{{Tag: "work", Count: 2}, {Tag: "errands", Count: 1}, {Tag: "urgent", Count: 1}}
+	if len(counts) != len(want) {
+		t.Fatalf("expected %v, got %v", want, counts)
+	}
+
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Errorf("index %d: expected %v, got %v", i, want[i], counts[i])
+		}
+	}
+
+	byTag := ticktick.TasksByTag(tasks)
+	if len(byTag["work"]) != 2 || len(byTag["errands"]) != 1 || len(byTag) != 3 {
+		t.Errorf("unexpected grouping: %v", byTag)
+	}
+}
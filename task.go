@@ -19,19 +19,34 @@ func (c *Client) GetTask(ctx context.Context, projectID, taskID string) (*Task,
 	return &task, nil
 }
 
-// CreateTask creates a new task.
+// CreateTask creates a new task. The request is validated before it is sent; see [WithValidation].
 func (c *Client) CreateTask(ctx context.Context, req *CreateTaskRequest) (*Task, error) {
+	if err := c.validateRequest(req); err != nil {
+		return nil, err
+	}
+
 	var task Task
 
 	if err := c.post(ctx, "/open/v1/task", req, &task); err != nil {
 		return nil, err
 	}
 
+	projectID := task.ProjectID
+	if projectID == "" && req != nil {
+		projectID = req.ProjectID
+	}
+
+	c.notifyChange(Change{ProjectID: projectID, TaskID: task.ID, Created: true})
+
 	return &task, nil
 }
 
-// UpdateTask updates an existing task.
+// UpdateTask updates an existing task. The request is validated before it is sent; see [WithValidation].
 func (c *Client) UpdateTask(ctx context.Context, taskID string, req *UpdateTaskRequest) (*Task, error) {
+	if err := c.validateRequest(req); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("/open/v1/task/%s", url.PathEscape(taskID))
 
 	var task Task
@@ -40,6 +55,18 @@ func (c *Client) UpdateTask(ctx context.Context, taskID string, req *UpdateTaskR
 		return nil, err
 	}
 
+	change := Change{ProjectID: task.ProjectID, TaskID: taskID}
+
+	if req != nil {
+		if change.ProjectID == "" {
+			change.ProjectID = req.ProjectID
+		} else if req.ProjectID != "" && req.ProjectID != change.ProjectID {
+			change.PreviousProjectID = req.ProjectID
+		}
+	}
+
+	c.notifyChange(change)
+
 	return &task, nil
 }
 
@@ -47,12 +74,68 @@ func (c *Client) UpdateTask(ctx context.Context, taskID string, req *UpdateTaskR
 func (c *Client) CompleteTask(ctx context.Context, projectID, taskID string) error {
 	path := fmt.Sprintf("/open/v1/project/%s/task/%s/complete", url.PathEscape(projectID), url.PathEscape(taskID))
 
-	return c.post(ctx, path, nil, nil)
+	if err := c.post(ctx, path, nil, nil); err != nil {
+		return err
+	}
+
+	c.notifyChange(Change{ProjectID: projectID, TaskID: taskID})
+
+	return nil
 }
 
 // DeleteTask deletes a task.
 func (c *Client) DeleteTask(ctx context.Context, projectID, taskID string) error {
 	path := fmt.Sprintf("/open/v1/project/%s/task/%s", url.PathEscape(projectID), url.PathEscape(taskID))
 
-	return c.delete(ctx, path)
+	if err := c.delete(ctx, path); err != nil {
+		return err
+	}
+
+	c.notifyChange(Change{ProjectID: projectID, TaskID: taskID})
+
+	return nil
+}
+
+// AllTasks returns the tasks of every open project of the authenticated user.
+// It fetches the project list and then each project's data in turn.
+func (c *Client) AllTasks(ctx context.Context) ([]Task, error) {
+	projects, err := c.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []Task
+
+	for _, p := range projects {
+		if p.Closed {
+			continue
+		}
+
+		data, err := c.GetProjectData(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, data.Tasks...)
+	}
+
+	return tasks, nil
+}
+
+// CompletedTasks lists tasks completed between req.StartDate and req.EndDate
+// in the projects req.ProjectIDs. A nil request lists all completed tasks the
+// API returns. Accounts whose API does not offer this endpoint get a 404
+// [*Error]; the history package provides a fallback for them.
+func (c *Client) CompletedTasks(ctx context.Context, req *CompletedTasksRequest) ([]Task, error) {
+	if req == nil {
+		req = &CompletedTasksRequest{}
+	}
+
+	var tasks []Task
+
+	if err := c.post(ctx, "/open/v1/task/completed", req, &tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
 }
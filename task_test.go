@@ -495,3 +495,90 @@ func TestGetTaskError(t *testing.T) {
 		t.Errorf("expected body \"task not found\", got %s", apiErr.Body)
 	}
 }
+
+func TestAllTasks(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/open/v1/project":
+			json.NewEncoder(w).Encode([]ticktick.Project{
+				{ID: "proj1"},
+				{ID: "proj2", Closed: true},
+				{ID: "proj3"},
+			})
+		case "/open/v1/project/proj1/data":
+			json.NewEncoder(w).Encode(ticktick.ProjectData{Tasks: []ticktick.Task{{ID: "t1"}, {ID: "t2"}}})
+		case "/open/v1/project/proj3/data":
+			json.NewEncoder(w).Encode(ticktick.ProjectData{Tasks: []ticktick.Task{{ID: "t3"}}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	tasks, err := client.AllTasks(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tasks) != 3 || tasks[2].ID != "t3" {
+		t.Errorf("unexpected tasks: %+v", tasks)
+	}
+}
+
+func TestAllTasksError(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/open/v1/project" {
+			json.NewEncoder(w).Encode([]ticktick.Project{{ID: "proj1"}})
+
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer server.Close()
+
+	_, err := client.AllTasks(context.Background())
+
+	var apiErr *ticktick.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected HTTP 500 error, got %v", err)
+	}
+}
+
+func TestCompletedTasks(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/open/v1/task/completed" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+
+		if body["startDate"] != "2024-03-01T00:00:00+0000" {
+			t.Errorf("unexpected startDate: %v", body["startDate"])
+		}
+
+		if ids, ok := body["projectIds"].([]any); !ok || len(ids) != 1 || ids[0] != "proj1" {
+			t.Errorf("unexpected projectIds: %v", body["projectIds"])
+		}
+
+		if _, ok := body["endDate"]; ok {
+			t.Error("expected endDate to be omitted")
+		}
+
+		json.NewEncoder(w).Encode([]ticktick.Task{{ID: "task1", Status: ticktick.TaskStatusCompleted}})
+	})
+	defer server.Close()
+
+	tasks, err := client.CompletedTasks(context.Background(), &ticktick.CompletedTasksRequest{
+		ProjectIDs: []string{"proj1"},
+		StartDate:  ticktick.NewTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tasks) != 1 || tasks[0].ID != "task1" {
+		t.Errorf("unexpected tasks: %+v", tasks)
+	}
+}
@@ -0,0 +1,280 @@
+package taskpaper
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// ErrSyntax is returned by [Decode] for malformed tag values.
+var ErrSyntax = errors.New("taskpaper: syntax error")
+
+var tagPattern = regexp.MustCompile(`(?:^|\s)@([\p{L}\p{N}_.-]+)(?:\(([^)]*)\))?`)
+
+// entry is a task being decoded together with its note lines and the
+// location its dates were read in.
+type entry struct {
+	node  *ticktick.TaskNode
+	depth int
+	loc   *time.Location
+	notes []string
+}
+
+// Decode reads a TaskPaper document. Unindented lines ending with a colon are
+// projects; lines starting with "- " are tasks, nested under the closest less
+// indented task above them; other lines are notes of the task above them,
+// without the backslash of lines escaped by [Encode].
+// Indentation is counted in tabs. Indented lines ending with a colon are read
+// as notes, because TickTick has no nested projects.
+//
+// Dates are read in the task's @timezone or, without one, in loc; a nil loc
+// means UTC. Checklist items use the zone of their task. A task without @start
+// starts on its due date, like tasks created in the TickTick apps. Lines tagged @item
+// become checklist items of their parent task, and the notes become the
+// description of a checklist task or the content of any other task. Subtasks
+// of tasks without an @id have an empty ParentID; use the returned tree to
+// create them after their parent.
+func Decode(r io.Reader, loc *time.Location) ([]Project, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var (
+		projects []Project
+		entries  []*entry
+		stack    []*entry
+	)
+
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimLeft(scanner.Text(), "\t")
+		depth := len(scanner.Text()) - len(text)
+		text = strings.TrimRight(text, " \t")
+
+		if text == "" && depth == 0 {
+			continue
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].depth >= depth {
+			stack = stack[:len(stack)-1]
+		}
+
+		var parent *entry
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+
+		switch {
+		case parent != nil && strings.HasPrefix(text, `\`) && needsEscape(text):
+			parent.notes = append(parent.notes, text[1:])
+		case text == "-" || strings.HasPrefix(text, "- "):
+			if len(projects) == 0 {
+				projects = append(projects, Project{})
+			}
+
+			body := strings.TrimPrefix(text, "-")
+			isItem := parent != nil && hasTag(body, TagItem)
+
+			taskLoc := location(body, loc)
+			if isItem {
+				taskLoc = parent.loc
+			}
+
+			task, err := decodeTask(body, taskLoc)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrSyntax, line, err)
+			}
+
+			if isItem {
+				parent.node.Task.Items = append(parent.node.Task.Items, checklistItem(&task))
+
+				continue
+			}
+
+			e := &entry{node: &ticktick.TaskNode{Task: task}, depth: depth, loc: taskLoc}
+			e.node.Task.ProjectID = projects[len(projects)-1].Project.ID
+			entries = append(entries, e)
+			stack = append(stack, e)
+
+			if parent == nil {
+				project := &projects[len(projects)-1]
+				project.Tasks = append(project.Tasks, e.node)
+			} else {
+				e.node.Task.ParentID = parent.node.Task.ID
+				parent.node.Children = append(parent.node.Children, e.node)
+			}
+		case depth == 0 && strings.HasSuffix(tagPattern.ReplaceAllString(text, ""), ":"):
+			projects = append(projects, decodeProject(text))
+			stack = nil
+		case parent != nil:
+			parent.notes = append(parent.notes, text)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("taskpaper: read document: %w", err)
+	}
+
+	for _, e := range entries {
+		setNotes(&e.node.Task, strings.Trim(strings.Join(e.notes, "\n"), "\n"))
+	}
+
+	return projects, nil
+}
+
+func decodeProject(text string) Project {
+	var p Project
+
+	for _, m := range tagPattern.FindAllStringSubmatch(text, -1) {
+		if strings.EqualFold(m[1], TagID) {
+			p.Project.ID = m[2]
+		}
+	}
+
+	p.Project.Name = strings.TrimSuffix(strings.TrimSpace(tagPattern.ReplaceAllString(text, "")), ":")
+
+	return p
+}
+
+// decodeTask parses a task line without its leading dash, reading dates in loc.
+func decodeTask(text string, loc *time.Location) (ticktick.Task, error) {
+	var task ticktick.Task
+
+	allDay := true
+
+	for _, m := range tagPattern.FindAllStringSubmatch(text, -1) {
+		name, value := strings.ToLower(m[1]), m[2]
+
+		switch name {
+		case TagDue, TagStart:
+			t, dateOnly, err := parseTime(value, loc)
+			if err != nil {
+				return task, err
+			}
+
+			allDay = allDay && dateOnly
+
+			if name == TagDue {
+				task.DueDate = ticktick.Time{Time: t}
+			} else {
+				task.StartDate = ticktick.Time{Time: t}
+			}
+		case TagDone:
+			task.Status = ticktick.TaskStatusCompleted
+
+			if value != "" {
+				t, _, err := parseTime(value, loc)
+				if err != nil {
+					return task, err
+				}
+
+				task.CompletedTime = ticktick.Time{Time: t}
+			}
+		case TagPriority:
+			task.Priority = priority(value)
+		case TagRepeat:
+			task.RepeatFlag = value
+		case TagID:
+			task.ID = value
+		case TagTimeZone:
+			task.TimeZone = value
+		case TagItem:
+			// Read by Decode.
+		default:
+			task.Tags = append(task.Tags, m[1])
+		}
+	}
+
+	task.Title = strings.TrimSpace(tagPattern.ReplaceAllString(text, ""))
+
+	if task.StartDate.IsZero() {
+		task.StartDate = task.DueDate
+	}
+
+	task.IsAllDay = allDay && !task.StartDate.IsZero()
+
+	return task, nil
+}
+
+// hasTag reports whether a line carries the named tag.
+func hasTag(text, name string) bool {
+	for _, m := range tagPattern.FindAllStringSubmatch(text, -1) {
+		if strings.EqualFold(m[1], name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// location returns the zone named by a line's @timezone tag, or loc if the
+// line has none or the zone is unknown.
+func location(text string, loc *time.Location) *time.Location {
+	for _, m := range tagPattern.FindAllStringSubmatch(text, -1) {
+		if !strings.EqualFold(m[1], TagTimeZone) {
+			continue
+		}
+
+		if zone, err := time.LoadLocation(m[2]); err == nil {
+			return zone
+		}
+	}
+
+	return loc
+}
+
+func checklistItem(task *ticktick.Task) ticktick.ChecklistItem {
+	item := ticktick.ChecklistItem{Title: task.Title, CompletedTime: task.CompletedTime}
+	if task.Status == ticktick.TaskStatusCompleted {
+		item.Status = ticktick.ChecklistStatusCompleted
+	}
+
+	return item
+}
+
+func setNotes(task *ticktick.Task, text string) {
+	if len(task.Items) > 0 {
+		task.Kind = ticktick.TaskKindChecklist
+		task.Desc = text
+
+		return
+	}
+
+	task.Content = text
+}
+
+// parseTime parses a "2006-01-02" or "2006-01-02 15:04" tag value and
+// reports whether it is a date without a time of day.
+func parseTime(value string, loc *time.Location) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation(dateLayout, value, loc); err == nil {
+		return t, true, nil
+	}
+
+	t, err := time.ParseInLocation(dateTimeLayout, value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+	}
+
+	return t, false, nil
+}
+
+// priority reads a priority name or a number from 1 (high) to 3 (low).
+func priority(value string) int {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "high", "1":
+		return ticktick.PriorityHigh
+	case "medium", "2":
+		return ticktick.PriorityMedium
+	case "low", "3":
+		return ticktick.PriorityLow
+	default:
+		return ticktick.PriorityNone
+	}
+}
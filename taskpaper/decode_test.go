@@ -0,0 +1,190 @@
+package taskpaper_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/taskpaper"
+)
+
+func TestDecode(t *testing.T) {
+	projects, err := taskpaper.Decode(strings.NewReader(testDocument), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(projects) != 1 || projects[0].Project.ID != "p1" || projects[0].Project.Name != "Work" {
+		t.Fatalf("unexpected projects: %+v", projects)
+	}
+
+	roots := projects[0].Tasks
+	if len(roots) != 2 || len(roots[0].Children) != 1 {
+		t.Fatalf("unexpected tree: %+v", roots)
+	}
+
+	release, notes := roots[0].Task, roots[0].Children[0].Task
+
+	if release.ID != "t1" || release.ProjectID != "p1" || release.Priority != ticktick.PriorityHigh ||
+		release.Kind != ticktick.TaskKindChecklist || release.Desc != "Notes are in the wiki.\n\nSee you there." ||
+		strings.Join(release.Tags, ",") != "release,q1_goals" || release.IsAllDay ||
+		release.StartDate.Hour() != 9 || release.DueDate.Hour() != 17 {
+		t.Errorf("unexpected task: %+v", release)
+	}
+
+	if len(release.Items) != 2 || release.Items[0].Status != ticktick.ChecklistStatusCompleted ||
+		release.Items[0].CompletedTime.Hour() != 8 || release.Items[1].Title != "Announce" {
+		t.Errorf("unexpected items: %+v", release.Items)
+	}
+
+	if notes.ParentID != "t1" || notes.Status != ticktick.TaskStatusCompleted || !notes.IsAllDay ||
+		!notes.StartDate.Equal(notes.DueDate.Time) || notes.Content != "Draft first" {
+		t.Errorf("unexpected subtask: %+v", notes)
+	}
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	projects, err := taskpaper.Decode(strings.NewReader(testDocument), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data := &ticktick.ProjectData{Project: projects[0].Project}
+	for _, root := range projects[0].Tasks {
+		data.Tasks = append(data.Tasks, root.Task)
+		data.Tasks = append(data.Tasks, root.Descendants()...)
+	}
+
+	for i := range data.Tasks {
+		data.Tasks[i].SortOrder = int64(i)
+	}
+
+	var buf bytes.Buffer
+	if err := taskpaper.Encode(&buf, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if buf.String() != testDocument {
+		t.Errorf("expected:\n%s\ngot:\n%s", testDocument, buf.String())
+	}
+}
+
+func TestDecodeRoundTripTimeZone(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	due := time.Date(2024, 1, 15, 17, 0, 0, 0, berlin)
+	done := time.Date(2024, 1, 15, 8, 0, 0, 0, berlin)
+
+	data := &ticktick.ProjectData{
+		Project: ticktick.Project{ID: "p1", Name: "Work"},
+		Tasks: []ticktick.Task{{
+			ID:        "t1",
+			Title:     "Ship release",
+			TimeZone:  "Europe/Berlin",
+			StartDate: ticktick.Time{Time: due.UTC()},
+			DueDate:   ticktick.Time{Time: due.UTC()},
+			Items: []ticktick.ChecklistItem{{
+				Title:         "Tag the commit",
+				Status:        ticktick.ChecklistStatusCompleted,
+				CompletedTime: ticktick.Time{Time: done.UTC()},
+			}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := taskpaper.Encode(&buf, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "@due(2024-01-15 17:00) @timezone(Europe/Berlin)") {
+		t.Errorf("expected the due date in the task's zone, got:\n%s", buf.String())
+	}
+
+	projects, err := taskpaper.Decode(&buf, time.UTC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task := projects[0].Tasks[0].Task
+	if task.TimeZone != "Europe/Berlin" || !task.DueDate.Equal(due) || !task.StartDate.Equal(due) {
+		t.Errorf("unexpected task: %+v", task)
+	}
+
+	if len(task.Items) != 1 || !task.Items[0].CompletedTime.Equal(done) {
+		t.Errorf("unexpected items: %+v", task.Items)
+	}
+}
+
+func TestDecodeRoundTripEscapedNotes(t *testing.T) {
+	content := "- buy milk\n-\n\t- indented\n\\- backslash\n-not a task"
+	data := &ticktick.ProjectData{
+		Project: ticktick.Project{Name: "Errands"},
+		Tasks:   []ticktick.Task{{Title: "Shop", Content: content}},
+	}
+
+	var buf bytes.Buffer
+	if err := taskpaper.Encode(&buf, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "\t\t\\- buy milk\n") {
+		t.Errorf("expected the note to be escaped, got:\n%s", buf.String())
+	}
+
+	projects, err := taskpaper.Decode(&buf, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tasks := projects[0].Tasks
+	if len(tasks) != 1 || len(tasks[0].Children) != 0 {
+		t.Fatalf("expected a single task, got %+v", tasks)
+	}
+
+	// Decode drops the indentation of notes.
+	if want := strings.Replace(content, "\t", "", 1); tasks[0].Task.Content != want {
+		t.Errorf("expected content %q, got %q", want, tasks[0].Task.Content)
+	}
+}
+
+func TestDecodeForeignDocument(t *testing.T) {
+	const document = "- Loose task @Errands\n" +
+		"\n" +
+		"Home:\n" +
+		"\t- Fix sink @priority(2) @due(2024-03-01 08:30) @done\n" +
+		"\t\tAgenda:\n" +
+		"\t\t- Buy washer @item\n" +
+		"\tStray note\n"
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	projects, err := taskpaper.Decode(strings.NewReader(document), berlin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(projects) != 2 || projects[0].Project.Name != "" || projects[1].Project.Name != "Home" {
+		t.Fatalf("unexpected projects: %+v", projects)
+	}
+
+	if loose := projects[0].Tasks[0].Task; loose.Title != "Loose task" || loose.Tags[0] != "Errands" {
+		t.Errorf("unexpected loose task: %+v", loose)
+	}
+
+	sink := projects[1].Tasks[0].Task
+	if sink.Priority != ticktick.PriorityMedium || sink.Status != ticktick.TaskStatusCompleted ||
+		!sink.CompletedTime.IsZero() || sink.DueDate.Location() != berlin || sink.Desc != "Agenda:" ||
+		len(sink.Items) != 1 {
+		t.Errorf("unexpected task: %+v", sink)
+	}
+}
+
+func TestDecodeInvalidDate(t *testing.T) {
+	const document = "P:\n\t- Bad @due(tomorrow)\n"
+
+	if _, err := taskpaper.Decode(strings.NewReader(document), nil); !errors.Is(err, taskpaper.ErrSyntax) {
+		t.Errorf("expected ErrSyntax, got %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+// Package taskpaper converts TickTick projects and tasks to and from
+// TaskPaper documents, so that tasks kept in TaskPaper files can be synced in
+// both directions.
+//
+// [Encode] writes a project line per project and a "- " line per task,
+// indented with tabs below the project or the parent task, followed by the
+// task's notes:
+//
+//	Work:
+//		- Ship release @priority(high) @due(2024-01-15 17:00) @release @id(6247ee29630c8063a2b3c4d2)
+//			Release notes are in the wiki.
+//			- Tag the commit @item @done
+//			- Write announcement @done(2024-01-14 10:30) @id(6247ee29630c8063a2b3c4d3)
+//
+// Dates are written in the task's time zone as @start, @due and @done values,
+// with the zone in @timezone; @start is left out when it equals the due date.
+// Priorities are @priority(high), @priority(medium) and @priority(low), the
+// repeat rule is @repeat and the task ID @id. Other tags are written as plain
+// tags, with characters TaskPaper does not allow in tag names replaced by
+// underscores. Checklist items are child lines tagged @item. Note lines that
+// would be read as tasks are escaped with a leading backslash.
+//
+// [Decode] reads such a document back, keeping the IDs so that a sync script
+// can tell updated tasks from new ones.
+package taskpaper
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// Tags with a special meaning.
+const (
+	TagDue      = "due"
+	TagStart    = "start"
+	TagDone     = "done"
+	TagPriority = "priority"
+	TagRepeat   = "repeat"
+	TagID       = "id"
+	TagItem     = "item"
+	TagTimeZone = "timezone"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02 15:04"
+)
+
+var invalidTagChars = regexp.MustCompile(`[^\p{L}\p{N}_.-]`)
+
+// Project is a project of a document with the tasks below it.
+type Project struct {
+	// Project holds the project's name and, if tagged, ID. Tasks above the
+	// first project line belong to a Project with a zero Project.
+	Project ticktick.Project
+	Tasks   []*ticktick.TaskNode
+}
+
+// Encode writes the projects and their tasks to w as a TaskPaper document.
+func Encode(w io.Writer, projects ...*ticktick.ProjectData) error {
+	var b strings.Builder
+
+	for _, data := range projects {
+		b.WriteString(data.Project.Name + ":")
+
+		if data.Project.ID != "" {
+			b.WriteString(" " + tag(TagID, data.Project.ID))
+		}
+
+		b.WriteString("\n")
+
+		for _, root := range data.TaskTree() {
+			root.Walk(func(node *ticktick.TaskNode, depth int) bool {
+				writeTask(&b, &node.Task, depth+1)
+
+				return true
+			})
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("taskpaper: write document: %w", err)
+	}
+
+	return nil
+}
+
+func writeTask(b *strings.Builder, t *ticktick.Task, depth int) {
+	indent := strings.Repeat("\t", depth)
+	loc := taskLocation(t)
+	tags := []string{}
+
+	if name := priorityName(t.Priority); name != "" {
+		tags = append(tags, tag(TagPriority, name))
+	}
+
+	if !t.StartDate.IsZero() && !t.StartDate.Equal(t.DueDate.Time) {
+		tags = append(tags, tag(TagStart, formatTime(t.StartDate.Time, t.IsAllDay, loc)))
+	}
+
+	if !t.DueDate.IsZero() {
+		tags = append(tags, tag(TagDue, formatTime(t.DueDate.Time, t.IsAllDay, loc)))
+	}
+
+	if t.RepeatFlag != "" {
+		tags = append(tags, tag(TagRepeat, t.RepeatFlag))
+	}
+
+	if t.Status == ticktick.TaskStatusCompleted {
+		tags = append(tags, doneTag(t.CompletedTime, loc))
+	}
+
+	if t.TimeZone != "" {
+		tags = append(tags, tag(TagTimeZone, t.TimeZone))
+	}
+
+	for _, name := range t.Tags {
+		tags = append(tags, "@"+invalidTagChars.ReplaceAllString(name, "_"))
+	}
+
+	if t.ID != "" {
+		tags = append(tags, tag(TagID, t.ID))
+	}
+
+	b.WriteString(indent + "- " + strings.Join(append([]string{t.Title}, tags...), " ") + "\n")
+
+	text := t.Content
+	if len(t.Items) > 0 {
+		text = t.Desc
+	}
+
+	for line := range strings.Lines(strings.TrimRight(text, "\n")) {
+		b.WriteString(indent + "\t" + escapeNote(strings.TrimRight(line, "\n")) + "\n")
+	}
+
+	for _, item := range t.Items {
+		line := indent + "\t- " + item.Title + " @" + TagItem
+		if item.Status == ticktick.ChecklistStatusCompleted {
+			line += " " + doneTag(item.CompletedTime, loc)
+		}
+
+		b.WriteString(line + "\n")
+	}
+}
+
+// escapeNote puts a backslash before a note line that [Decode] would
+// otherwise read as a task.
+func escapeNote(line string) string {
+	text := strings.TrimLeft(line, "\t")
+	if !needsEscape(text) {
+		return line
+	}
+
+	return line[:len(line)-len(text)] + `\` + text
+}
+
+// needsEscape reports whether a note line, without its indentation, has to
+// be escaped: it looks like a task, or is such a line already escaped.
+func needsEscape(text string) bool {
+	if rest, ok := strings.CutPrefix(text, `\`); ok {
+		return needsEscape(rest)
+	}
+
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func tag(name, value string) string {
+	return "@" + name + "(" + value + ")"
+}
+
+func doneTag(completed ticktick.Time, loc *time.Location) string {
+	if completed.IsZero() {
+		return "@" + TagDone
+	}
+
+	return tag(TagDone, completed.In(loc).Format(dateTimeLayout))
+}
+
+func formatTime(t time.Time, allDay bool, loc *time.Location) string {
+	if allDay {
+		return t.In(loc).Format(dateLayout)
+	}
+
+	return t.In(loc).Format(dateTimeLayout)
+}
+
+func priorityName(priority int) string {
+	switch priority {
+	case ticktick.PriorityHigh:
+		return "high"
+	case ticktick.PriorityMedium:
+		return "medium"
+	case ticktick.PriorityLow:
+		return "low"
+	default:
+		return ""
+	}
+}
+
+func taskLocation(t *ticktick.Task) *time.Location {
+	if t.TimeZone != "" {
+		if loc, err := time.LoadLocation(t.TimeZone); err == nil {
+			return loc
+		}
+	}
+
+	if !t.DueDate.IsZero() {
+		return t.DueDate.Location()
+	}
+
+	return t.StartDate.Location()
+}
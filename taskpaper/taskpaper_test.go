@@ -0,0 +1,73 @@
+package taskpaper_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/taskpaper"
+)
+
+func testProject() *ticktick.ProjectData {
+	return &ticktick.ProjectData{
+		Project: ticktick.Project{ID: "p1", Name: "Work"},
+		Tasks: []ticktick.Task{
+			{
+				ID:         "t1",
+				Title:      "Ship release",
+				Kind:       ticktick.TaskKindChecklist,
+				Desc:       "Notes are in the wiki.\n\nSee you there.",
+				StartDate:  ticktick.Time{Time: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+				DueDate:    ticktick.Time{Time: time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)},
+				Priority:   ticktick.PriorityHigh,
+				RepeatFlag: "RRULE:FREQ=WEEKLY;INTERVAL=1",
+				Tags:       []string{"release", "q1 goals"},
+				SortOrder:  1,
+				Items: []ticktick.ChecklistItem{
+					{
+						Title:         "Tag the commit",
+						Status:        ticktick.ChecklistStatusCompleted,
+						CompletedTime: ticktick.Time{Time: time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)},
+					},
+					{Title: "Announce"},
+				},
+			},
+			{
+				ID:            "t2",
+				Title:         "Write notes",
+				ParentID:      "t1",
+				Content:       "Draft first",
+				Status:        ticktick.TaskStatusCompleted,
+				CompletedTime: ticktick.Time{Time: time.Date(2024, 1, 14, 10, 30, 0, 0, time.UTC)},
+				IsAllDay:      true,
+				StartDate:     ticktick.Time{Time: time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+				DueDate:       ticktick.Time{Time: time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+			},
+			{ID: "t3", Title: "Triage", Priority: ticktick.PriorityLow, SortOrder: 2},
+		},
+	}
+}
+
+const testDocument = "Work: @id(p1)\n" +
+	"\t- Ship release @priority(high) @start(2024-01-15 09:00) @due(2024-01-15 17:00) " +
+	"@repeat(RRULE:FREQ=WEEKLY;INTERVAL=1) @release @q1_goals @id(t1)\n" +
+	"\t\tNotes are in the wiki.\n" +
+	"\t\t\n" +
+	"\t\tSee you there.\n" +
+	"\t\t- Tag the commit @item @done(2024-01-15 08:00)\n" +
+	"\t\t- Announce @item\n" +
+	"\t\t- Write notes @due(2024-01-14) @done(2024-01-14 10:30) @id(t2)\n" +
+	"\t\t\tDraft first\n" +
+	"\t- Triage @priority(low) @id(t3)\n"
+
+func TestEncode(t *testing.T) {
+	var buf bytes.Buffer
+	if err := taskpaper.Encode(&buf, testProject()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if buf.String() != testDocument {
+		t.Errorf("expected:\n%s\ngot:\n%s", testDocument, buf.String())
+	}
+}
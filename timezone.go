@@ -0,0 +1,46 @@
+package ticktick
+
+import "time"
+
+// loadLocation returns the named IANA location, or nil if the name is empty or unknown.
+func loadLocation(name string) *time.Location {
+	if name == "" {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil
+	}
+
+	return loc
+}
+
+// inLocation converts every non-zero time to loc in place.
+func inLocation(loc *time.Location, times ...*Time) {
+	for _, t := range times {
+		if !t.IsZero() {
+			t.Time = t.In(loc)
+		}
+	}
+}
+
+// timeZoneOf returns the IANA name of the first non-zero time's location. The
+// process-local zone and unnamed fixed offsets are skipped because they have no
+// IANA name the API would accept.
+func timeZoneOf(times ...*Time) *string {
+	for _, t := range times {
+		if t == nil || t.IsZero() {
+			continue
+		}
+
+		name := t.Location().String()
+		if name == "Local" || loadLocation(name) == nil {
+			continue
+		}
+
+		return String(name)
+	}
+
+	return nil
+}
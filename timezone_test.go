@@ -0,0 +1,187 @@
+package ticktick_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	return loc
+}
+
+func TestTaskUnmarshalJSONTimeZone(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	var task ticktick.Task
+
+	err := json.Unmarshal([]byte(`{
+		"id": "task1",
+		"timeZone": "America/New_York",
+		"dueDate": "2024-01-15T14:00:00.000+0000",
+		"items": [
+			{"id": "item1", "startDate": "2024-01-15T14:00:00.000+0000"},
+			{"id": "item2", "timeZone": "UTC", "startDate": "2024-01-15T14:00:00.000+0000"}
+		]
+	}`), &task)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.ID != "task1" {
+		t.Errorf("expected task ID task1, got %s", task.ID)
+	}
+
+	if task.DueDate.Location().String() != ny.String() || task.DueDate.Hour() != 9 {
+		t.Errorf("expected 09:00 in America/New_York, got %v", task.DueDate.Time)
+	}
+
+	if task.Items[0].StartDate.Location().String() != ny.String() {
+		t.Errorf("expected item to inherit task zone, got %v", task.Items[0].StartDate.Location())
+	}
+
+	if task.Items[1].StartDate.Location() != time.UTC {
+		t.Errorf("expected item to keep its own zone, got %v", task.Items[1].StartDate.Location())
+	}
+}
+
+func TestTaskUnmarshalJSONUnknownTimeZone(t *testing.T) {
+	var task ticktick.Task
+
+	err := json.Unmarshal([]byte(`{"timeZone":"Mars/Olympus","dueDate":"2024-01-15T14:00:00+0000"}`), &task)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.DueDate.Hour() != 14 {
+		t.Errorf("expected time to be left as decoded, got %v", task.DueDate.Time)
+	}
+}
+
+func TestCreateTaskRequestFillsTimeZone(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+
+	req := ticktick.CreateTaskRequest{
+		Title:     "Task",
+		ProjectID: "proj1",
+		DueDate:   ticktick.NewTime(time.Date(2024, 1, 15, 9, 0, 0, 0, tokyo)),
+		Items: []ticktick.CreateChecklistItemRequest{
+			{Title: "Item", StartDate: ticktick.NewTime(time.Date(2024, 1, 15, 9, 0, 0, 0, tokyo))},
+		},
+	}
+
+	data, err := json.Marshal(&req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded struct {
+		TimeZone string `json:"timeZone"`
+		DueDate  string `json:"dueDate"`
+		Items    []struct {
+			TimeZone string `json:"timeZone"`
+		} `json:"items"`
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded.TimeZone != "Asia/Tokyo" {
+		t.Errorf("expected timeZone Asia/Tokyo, got %q", decoded.TimeZone)
+	}
+
+	if decoded.DueDate != "2024-01-15T09:00:00+0900" {
+		t.Errorf("unexpected dueDate %q", decoded.DueDate)
+	}
+
+	if len(decoded.Items) != 1 || decoded.Items[0].TimeZone != "Asia/Tokyo" {
+		t.Errorf("expected item timeZone Asia/Tokyo, got %+v", decoded.Items)
+	}
+}
+
+func TestUpdateTaskRequestKeepsExplicitTimeZone(t *testing.T) {
+	req := ticktick.UpdateTaskRequest{
+		ID:        "task1",
+		ProjectID: "proj1",
+		TimeZone:  ticktick.String("Europe/London"),
+		DueDate:   ticktick.NewTime(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)),
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded["timeZone"] != "Europe/London" {
+		t.Errorf("expected explicit timeZone to be kept, got %v", decoded["timeZone"])
+	}
+}
+
+func TestRequestSkipsUnnamedZones(t *testing.T) {
+	req := ticktick.CreateTaskRequest{
+		Title:     "Task",
+		ProjectID: "proj1",
+		DueDate:   ticktick.NewTime(time.Date(2024, 1, 15, 9, 0, 0, 0, time.FixedZone("", 3600))),
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := decoded["timeZone"]; ok {
+		t.Errorf("expected no timeZone for unnamed offset, got %v", decoded["timeZone"])
+	}
+}
+
+func TestTaskRoundTripKeepsWallClock(t *testing.T) {
+	loadLocation(t, "Europe/Berlin")
+
+	var task ticktick.Task
+
+	body := `{"id":"t","projectId":"p","timeZone":"Europe/Berlin","dueDate":"2024-07-01T08:00:00+0000"}`
+
+	err := json.Unmarshal([]byte(body), &task)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := ticktick.UpdateTaskRequest{ID: task.ID, ProjectID: task.ProjectID, DueDate: &task.DueDate}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded["dueDate"] != "2024-07-01T10:00:00+0200" || decoded["timeZone"] != "Europe/Berlin" {
+		t.Errorf("unexpected round trip: %v / %v", decoded["dueDate"], decoded["timeZone"])
+	}
+}
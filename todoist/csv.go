@@ -0,0 +1,203 @@
+package todoist
+
+import (
+	"cmp"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Row types of a Todoist CSV export.
+const (
+	csvTask    = "task"
+	csvSection = "section"
+	csvNote    = "note"
+	csvMeta    = "meta"
+)
+
+// csvDateLayouts are the due date forms of a CSV export that are read as
+// dates; other due strings are kept in Due.String only.
+const csvDateLayouts = "2006-01-02|2006-01-02 15:04|Jan 2 2006|Jan 2 2006 15:04|2 Jan 2006|2 Jan 2006 15:04"
+
+const byteOrderMark = "\ufeff"
+
+var labelPattern = regexp.MustCompile(`(?:^|\s)@([\p{L}\p{N}_-]+)`)
+
+// ReadCSV reads the CSV export of a single Todoist project, which does not
+// contain the project's name; it is given as projectName.
+//
+// The CSV layout stores priorities in UI order (1 is p1) and subtasks as an
+// INDENT level; both are converted so the returned tasks look like those of
+// [ReadJSON]. Labels written into the task name ("Buy milk @errands") are
+// moved to Task.Labels. DATE holds the due date as typed by the user; it is
+// copied to Due.String and parsed into Due.Date when it is a plain date.
+func ReadCSV(r io.Reader, projectName string) (*Export, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("todoist: read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, byteOrderMark)))] = i
+	}
+
+	if _, ok := columns["TYPE"]; !ok {
+		return nil, errors.New("todoist: read csv: missing TYPE column")
+	}
+
+	b := &csvBuilder{
+		columns: columns,
+		project: Project{ID: ID("csv:" + projectName), Name: projectName},
+	}
+
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("todoist: read csv: %w", err)
+		}
+
+		b.add(record, line)
+	}
+
+	return &Export{
+		Projects: []Project{b.project},
+		Sections: b.sections,
+		Tasks:    b.tasks,
+		Comments: b.comments,
+	}, nil
+}
+
+type csvBuilder struct {
+	columns  map[string]int
+	project  Project
+	sections []Section
+	tasks    []Task
+	comments []Comment
+
+	section ID
+	// parents holds the last task seen at each indent level.
+	parents []ID
+}
+
+func (b *csvBuilder) get(record []string, column string) string {
+	i, ok := b.columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
+
+func (b *csvBuilder) add(record []string, line int) {
+	id := ID("row:" + strconv.Itoa(line))
+	content := b.get(record, "CONTENT")
+
+	switch strings.ToLower(b.get(record, "TYPE")) {
+	case csvSection:
+		b.section = id
+		b.parents = nil
+		b.sections = append(b.sections, Section{
+			ID: id, ProjectID: b.project.ID, Name: content, SectionOrder: len(b.sections),
+		})
+	case csvNote:
+		if len(b.tasks) > 0 {
+			last := b.tasks[len(b.tasks)-1].ID
+			b.comments = append(b.comments, Comment{ID: id, TaskID: last, Content: content})
+		}
+	case csvMeta:
+		if style, ok := strings.CutPrefix(content, "view_style="); ok {
+			b.project.ViewStyle = style
+		}
+	case csvTask:
+		b.tasks = append(b.tasks, b.task(record, id, content))
+	}
+}
+
+func (b *csvBuilder) task(record []string, id ID, content string) Task {
+	task := Task{
+		ID:          id,
+		ProjectID:   b.project.ID,
+		SectionID:   b.section,
+		Description: b.get(record, "DESCRIPTION"),
+		Priority:    PriorityP4,
+		ChildOrder:  len(b.tasks),
+		Responsible: ID(b.get(record, "RESPONSIBLE")),
+	}
+
+	for _, m := range labelPattern.FindAllStringSubmatch(content, -1) {
+		task.Labels = append(task.Labels, m[1])
+	}
+
+	task.Content = strings.Join(strings.Fields(labelPattern.ReplaceAllString(content, " ")), " ")
+
+	// The UI's p1 is the API's priority 4.
+	if p, err := strconv.Atoi(b.get(record, "PRIORITY")); err == nil && p >= 1 && p <= PriorityP1 {
+		task.Priority = PriorityP1 + 1 - p
+	}
+
+	indent, err := strconv.Atoi(b.get(record, "INDENT"))
+	if err != nil || indent < 1 {
+		indent = 1
+	}
+
+	if indent > len(b.parents)+1 {
+		indent = len(b.parents) + 1
+	}
+
+	b.parents = append(b.parents[:indent-1], id)
+	if indent > 1 {
+		task.ParentID = b.parents[indent-2]
+	}
+
+	if date := b.get(record, "DATE"); date != "" {
+		task.Due = &Due{
+			Date:        csvDate(date),
+			String:      date,
+			IsRecurring: strings.HasPrefix(strings.ToLower(date), "every"),
+			Timezone:    b.get(record, "TIMEZONE"),
+			Lang:        b.get(record, "DATE_LANG"),
+		}
+	}
+
+	if deadline := b.get(record, "DEADLINE"); deadline != "" {
+		task.Deadline = &Deadline{Date: cmp.Or(csvDate(deadline), deadline)}
+	}
+
+	if amount, err := strconv.Atoi(b.get(record, "DURATION")); err == nil && amount > 0 {
+		task.Duration = &Duration{Amount: amount, Unit: b.get(record, "DURATION_UNIT")}
+	}
+
+	return task
+}
+
+// csvDate converts a plain date, with or without a time, to the form used
+// by Due.Date. It returns "" for anything else.
+func csvDate(s string) string {
+	for layout := range strings.SplitSeq(csvDateLayouts, "|") {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+
+		if strings.Contains(layout, "15:04") {
+			return t.Format(dueDateTimeLayout)
+		}
+
+		return t.Format(dueDateLayout)
+	}
+
+	return ""
+}
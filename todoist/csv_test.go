@@ -0,0 +1,61 @@
+package todoist_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick/todoist"
+)
+
+func TestReadCSV(t *testing.T) {
+	const data = "\ufeffTYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE," +
+		"DURATION,DURATION_UNIT,DEADLINE,DEADLINE_LANG\n" +
+		"meta,view_style=board,,,,,,,,,,,,\n" +
+		"task,Plan trip @travel @home,Book early,1,1,Ann (1),,2024-01-15,en,Europe/Berlin,30,minute,,\n" +
+		"task,Pick hotel,,4,2,Ann (1),Bob (2),every mon,en,Europe/Berlin,,,2024-02-01,en\n" +
+		"note,Ask Carl,,,,Ann (1),,,,,,,,\n" +
+		",,,,,,,,,,,,,\n" +
+		"section,Later,,,,,,,,,,,,\n" +
+		"task,Pack,,2,2,Ann (1),,tomorrow at 9,en,,,,,\n"
+
+	export, err := todoist.ReadCSV(strings.NewReader(data), "Travel")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	project := export.Projects[0]
+	if project.Name != "Travel" || project.ViewStyle != "board" {
+		t.Errorf("unexpected project: %+v", project)
+	}
+
+	if len(export.Tasks) != 3 || len(export.Sections) != 1 || len(export.Comments) != 1 {
+		t.Fatalf("unexpected export: %+v", export)
+	}
+
+	plan, hotel, pack := export.Tasks[0], export.Tasks[1], export.Tasks[2]
+
+	if plan.Content != "Plan trip" || strings.Join(plan.Labels, ",") != "travel,home" ||
+		plan.Priority != todoist.PriorityP1 || plan.Due.Date != "2024-01-15" || plan.Duration.Amount != 30 {
+		t.Errorf("unexpected first task: %+v", plan)
+	}
+
+	if hotel.ParentID != plan.ID || hotel.Priority != todoist.PriorityP4 || !hotel.Due.IsRecurring ||
+		hotel.Due.Date != "" || hotel.Responsible != "Bob (2)" || hotel.Deadline.Date != "2024-02-01" {
+		t.Errorf("unexpected subtask: %+v", hotel)
+	}
+
+	if export.Comments[0].TaskID != hotel.ID || export.Comments[0].Content != "Ask Carl" {
+		t.Errorf("unexpected comment: %+v", export.Comments[0])
+	}
+
+	// A section starts a new hierarchy, so the indented task has no parent.
+	if pack.SectionID != export.Sections[0].ID || pack.ParentID != "" || pack.Priority != todoist.PriorityP2 {
+		t.Errorf("unexpected task in section: %+v", pack)
+	}
+}
+
+func TestReadCSVMissingType(t *testing.T) {
+	if _, err := todoist.ReadCSV(strings.NewReader("CONTENT\nA\n"), "P"); err == nil {
+		t.Error("expected an error for a file without a TYPE column")
+	}
+}
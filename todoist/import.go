@@ -0,0 +1,408 @@
+package todoist
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// Layouts of Due.Date.
+const (
+	dueDateLayout     = "2006-01-02"
+	dueDateTimeLayout = "2006-01-02T15:04:05"
+)
+
+const boardViewStyle = "board"
+
+// Kinds of Todoist objects in an [Unmapped] entry.
+const (
+	KindProject = "project"
+	KindSection = "section"
+	KindTask    = "task"
+	KindComment = "comment"
+)
+
+// ImportOptions configures [Import].
+type ImportOptions struct {
+	// Projects maps Todoist project IDs or names to the IDs of existing
+	// TickTick projects. The tasks of a mapped project are imported into the
+	// existing project instead of a new one, and its sections are matched to
+	// the existing project's columns by name. It may be nil.
+	Projects map[string]string
+	// Location is used for due dates and floating due times. Nil means UTC.
+	Location *time.Location
+}
+
+// Report describes the outcome of [Import].
+type Report struct {
+	// Projects maps Todoist project IDs to TickTick project IDs.
+	Projects map[string]string
+	// Tasks maps Todoist task IDs to TickTick task IDs.
+	Tasks map[string]string
+	// Unmapped lists the Todoist data that could not be carried over.
+	Unmapped []Unmapped
+}
+
+// Unmapped is a piece of Todoist data that has no TickTick counterpart or
+// could not be converted.
+type Unmapped struct {
+	// Kind is KindProject, KindSection, KindTask or KindComment.
+	Kind string
+	ID   string
+	// Name is the project or section name, the task content, or the comment text.
+	Name string
+	// Field is the Todoist field, e.g. "due.string" or "responsible_uid".
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (u Unmapped) String() string {
+	return fmt.Sprintf("%s %q: %s %q %s", u.Kind, u.Name, u.Field, u.Value, u.Reason)
+}
+
+// Import creates the export's projects and tasks through client and reports
+// what was created and what was left out.
+//
+// Each project becomes a new TickTick project (in kanban view if it was a
+// board), except the Todoist Inbox, whose tasks go to the TickTick Inbox, and
+// projects mapped by ImportOptions.Projects. The Open API cannot create kanban
+// columns, so sections are only mapped to columns of the same name that the
+// target project already has; the tasks of other sections are created without
+// a column. Priorities p1-p4 become high, medium, low and none, labels become
+// tags, and the description and comments become the task content. Recurring
+// due strings become repeat rules where [RepeatFlag] understands them.
+// Subtasks are created under their imported parent, and completed tasks are
+// completed after they are created.
+//
+// Nested projects, archived state, assignees, durations, deadlines and
+// comment attachments have no Open API counterpart and are reported as
+// unmapped. Importing stops at the first error; the report then describes
+// what was imported so far.
+func Import(ctx context.Context, client *ticktick.Client, export *Export, opts ImportOptions) (*Report, error) {
+	im := &importer{
+		client:   client,
+		opts:     opts,
+		export:   export,
+		comments: make(map[ID][]Comment),
+		report:   &Report{Projects: map[string]string{}, Tasks: map[string]string{}},
+	}
+
+	for _, c := range export.Comments {
+		im.comments[c.TaskID] = append(im.comments[c.TaskID], c)
+	}
+
+	projects := make(map[ID]bool, len(export.Projects))
+
+	for i := range export.Projects {
+		projects[export.Projects[i].ID] = true
+
+		if err := im.importProject(ctx, &export.Projects[i]); err != nil {
+			return im.report, err
+		}
+	}
+
+	for _, t := range export.Tasks {
+		if !projects[t.ProjectID] {
+			im.unmapped(KindTask, t.ID, t.Content, "project_id", string(t.ProjectID), "project not in the export")
+		}
+	}
+
+	return im.report, nil
+}
+
+type importer struct {
+	client   *ticktick.Client
+	opts     ImportOptions
+	export   *Export
+	comments map[ID][]Comment
+	report   *Report
+}
+
+func (im *importer) unmapped(kind string, id ID, name, field, value, reason string) {
+	im.report.Unmapped = append(im.report.Unmapped, Unmapped{
+		Kind: kind, ID: string(id), Name: name, Field: field, Value: value, Reason: reason,
+	})
+}
+
+func (im *importer) importProject(ctx context.Context, p *Project) error {
+	projectID, err := im.target(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	im.report.Projects[string(p.ID)] = projectID
+
+	if p.ParentID != "" {
+		im.unmapped(KindProject, p.ID, p.Name, "parent_id", string(p.ParentID), "nested projects are not supported")
+	}
+
+	if p.IsArchived {
+		im.unmapped(KindProject, p.ID, p.Name, "is_archived", "true", "imported as an open project")
+	}
+
+	columns, err := im.columns(ctx, p, projectID)
+	if err != nil {
+		return err
+	}
+
+	var tasks []ticktick.Task
+
+	byID := make(map[string]*Task)
+
+	for i := range im.export.Tasks {
+		t := &im.export.Tasks[i]
+		if t.ProjectID != p.ID {
+			continue
+		}
+
+		byID[string(t.ID)] = t
+		tasks = append(tasks, ticktick.Task{
+			ID: string(t.ID), ParentID: string(t.ParentID), SortOrder: int64(t.ChildOrder),
+		})
+	}
+
+	// Subtasks need the ID of their created parent, so parents go first.
+	return ticktick.WalkTasks(ticktick.BuildTaskTree(tasks), func(node *ticktick.TaskNode, _ int) error {
+		return im.importTask(ctx, byID[node.Task.ID], projectID, columns)
+	})
+}
+
+// target returns the ID of the TickTick project to import p into, creating
+// the project if needed.
+func (im *importer) target(ctx context.Context, p *Project) (string, error) {
+	if id, ok := im.opts.Projects[string(p.ID)]; ok {
+		return id, nil
+	}
+
+	if id, ok := im.opts.Projects[p.Name]; ok {
+		return id, nil
+	}
+
+	if p.InboxProject {
+		id, err := im.client.InboxID(ctx)
+		if err == nil {
+			return id, nil
+		}
+
+		if !errors.Is(err, ticktick.ErrInboxUnsupported) {
+			return "", fmt.Errorf("todoist: find inbox: %w", err)
+		}
+	}
+
+	req := &ticktick.CreateProjectRequest{
+		Name:     p.Name,
+		ViewMode: ticktick.String(ticktick.ViewModeList),
+	}
+
+	if p.ViewStyle == boardViewStyle {
+		req.ViewMode = ticktick.String(ticktick.ViewModeKanban)
+	}
+
+	if color, ok := Color(p.Color); ok {
+		req.Color = ticktick.String(color)
+	} else if p.Color != "" {
+		im.unmapped(KindProject, p.ID, p.Name, "color", p.Color, "unknown color")
+	}
+
+	created, err := im.client.CreateProject(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("todoist: create project %q: %w", p.Name, err)
+	}
+
+	return created.ID, nil
+}
+
+// columns maps the IDs of p's sections to the IDs of the columns with the
+// same name in the TickTick project.
+func (im *importer) columns(ctx context.Context, p *Project, projectID string) (map[ID]string, error) {
+	var sections []Section
+
+	for _, s := range im.export.Sections {
+		if s.ProjectID == p.ID {
+			sections = append(sections, s)
+		}
+	}
+
+	columns := make(map[ID]string, len(sections))
+	if len(sections) == 0 {
+		return columns, nil
+	}
+
+	data, err := im.client.GetProjectData(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("todoist: read project %s: %w", projectID, err)
+	}
+
+	byName := make(map[string]string, len(data.Columns))
+	for _, c := range data.Columns {
+		byName[strings.ToLower(c.Name)] = c.ID
+	}
+
+	for _, s := range sections {
+		id, ok := byName[strings.ToLower(s.Name)]
+		if !ok {
+			im.unmapped(KindSection, s.ID, s.Name, "name", s.Name, "no column of that name; columns cannot be created")
+
+			continue
+		}
+
+		columns[s.ID] = id
+	}
+
+	return columns, nil
+}
+
+func (im *importer) importTask(ctx context.Context, t *Task, projectID string, columns map[ID]string) error {
+	req := &ticktick.CreateTaskRequest{
+		Title:     t.Content,
+		ProjectID: projectID,
+		Priority:  ticktick.Int(Priority(t.Priority)),
+		Tags:      t.Labels,
+	}
+
+	if content := im.content(t); content != "" {
+		req.Content = ticktick.String(content)
+	}
+
+	if id, ok := columns[t.SectionID]; ok {
+		req.ColumnID = ticktick.String(id)
+	}
+
+	if id, ok := im.report.Tasks[string(t.ParentID)]; ok && t.ParentID != "" {
+		req.ParentID = ticktick.String(id)
+	}
+
+	im.setDue(req, t)
+	im.reportTask(t)
+
+	created, err := im.client.CreateTask(ctx, req)
+	if err != nil {
+		return fmt.Errorf("todoist: create task %q: %w", t.Content, err)
+	}
+
+	im.report.Tasks[string(t.ID)] = created.ID
+
+	if t.Checked {
+		if err := im.client.CompleteTask(ctx, projectID, created.ID); err != nil {
+			return fmt.Errorf("todoist: complete task %q: %w", t.Content, err)
+		}
+	}
+
+	return nil
+}
+
+// content joins the task's description and comments into paragraphs.
+func (im *importer) content(t *Task) string {
+	var parts []string
+
+	if s := strings.TrimSpace(t.Description); s != "" {
+		parts = append(parts, s)
+	}
+
+	for _, c := range im.comments[t.ID] {
+		if len(c.Attachment) > 0 && string(c.Attachment) != "null" {
+			im.unmapped(KindComment, c.ID, c.Content, "file_attachment", string(c.Attachment),
+				"attachments are not imported")
+		}
+
+		if s := strings.TrimSpace(c.Content); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// reportTask records the task fields Import does not carry over.
+func (im *importer) reportTask(t *Task) {
+	if t.Responsible != "" {
+		im.unmapped(KindTask, t.ID, t.Content, "responsible_uid", string(t.Responsible), "assignees are not imported")
+	}
+
+	if t.Duration != nil {
+		im.unmapped(KindTask, t.ID, t.Content, "duration", t.Duration.String(), "durations are not imported")
+	}
+
+	if t.Deadline != nil {
+		im.unmapped(KindTask, t.ID, t.Content, "deadline", t.Deadline.Date, "deadlines are not imported")
+	}
+}
+
+func (im *importer) setDue(req *ticktick.CreateTaskRequest, t *Task) {
+	d := t.Due
+	if d == nil {
+		return
+	}
+
+	if d.IsRecurring {
+		flag, ok := RepeatFlag(d.String)
+
+		switch {
+		case !ok:
+			im.unmapped(KindTask, t.ID, t.Content, "due.string", d.String, "recurrence not understood")
+		case strings.HasPrefix(strings.ToLower(strings.TrimSpace(d.String)), "every!"):
+			im.unmapped(KindTask, t.ID, t.Content, "due.string", d.String, "repeats on schedule, not after completion")
+		}
+
+		if ok {
+			req.RepeatFlag = ticktick.String(flag)
+		}
+	}
+
+	loc := im.opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if day, err := time.Parse(dueDateLayout, d.Date); err == nil {
+		req.SetAllDay(ticktick.DateOf(day), loc)
+
+		return
+	}
+
+	if d.Timezone != "" {
+		if zone, err := time.LoadLocation(d.Timezone); err == nil {
+			loc = zone
+		}
+	}
+
+	due, err := time.Parse(dueDateTimeLayout+"Z", d.Date)
+	if err != nil {
+		due, err = time.ParseInLocation(dueDateTimeLayout, d.Date, loc)
+	}
+
+	if err != nil {
+		im.unmapped(KindTask, t.ID, t.Content, "due", cmp.Or(d.Date, d.String), "date not understood")
+
+		return
+	}
+
+	// CreateTaskRequest fills TimeZone from the due date's location when it
+	// has an IANA name.
+	req.DueDate = ticktick.NewTime(due.In(loc))
+
+	if req.RepeatFlag != nil {
+		req.StartDate = req.DueDate
+	}
+}
+
+// Priority converts a Todoist priority (API scale, see [PriorityP1]) to
+// TickTick's: p1 is high, p2 medium, p3 low and p4 none.
+func Priority(p int) int {
+	switch p {
+	case PriorityP1:
+		return ticktick.PriorityHigh
+	case PriorityP2:
+		return ticktick.PriorityMedium
+	case PriorityP3:
+		return ticktick.PriorityLow
+	default:
+		return ticktick.PriorityNone
+	}
+}
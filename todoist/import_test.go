@@ -0,0 +1,236 @@
+package todoist_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	"github.com/slavkluev/go-ticktick/todoist"
+)
+
+// fakeAccount records the projects, tasks and completions an import creates.
+type fakeAccount struct {
+	projects  []ticktick.CreateProjectRequest
+	tasks     []ticktick.CreateTaskRequest
+	columns   map[string][]ticktick.Column
+	completed []string
+	// failTitle makes creating a task with this title fail.
+	failTitle string
+}
+
+func (f *fakeAccount) client(t *testing.T) *ticktick.Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /open/v1/project", func(w http.ResponseWriter, r *http.Request) {
+		var req ticktick.CreateProjectRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		f.projects = append(f.projects, req)
+		json.NewEncoder(w).Encode(ticktick.Project{ID: "new-" + req.Name, Name: req.Name})
+	})
+	mux.HandleFunc("POST /open/v1/task", func(w http.ResponseWriter, r *http.Request) {
+		var req ticktick.CreateTaskRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if f.failTitle != "" && req.Title == f.failTitle {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		f.tasks = append(f.tasks, req)
+		json.NewEncoder(w).Encode(ticktick.Task{ID: "new-" + req.Title, ProjectID: req.ProjectID})
+	})
+	mux.HandleFunc("GET /open/v1/project/{id}/data", func(w http.ResponseWriter, r *http.Request) {
+		if id := r.PathValue("id"); id != "inbox" {
+			json.NewEncoder(w).Encode(ticktick.ProjectData{Columns: f.columns[id]})
+
+			return
+		}
+
+		json.NewEncoder(w).Encode(ticktick.ProjectData{Project: ticktick.Project{ID: "inbox7"}})
+	})
+	mux.HandleFunc("POST /open/v1/project/{pid}/task/{tid}/complete", func(_ http.ResponseWriter, r *http.Request) {
+		f.completed = append(f.completed, r.URL.Path)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return ticktick.NewClient("token", ticktick.WithBaseURL(server.URL))
+}
+
+func testExport() *todoist.Export {
+	return &todoist.Export{
+		Projects: []todoist.Project{
+			{ID: "p1", Name: "Work", Color: "blue", ViewStyle: "board", ParentID: "p0"},
+			{ID: "p2", Name: "Inbox", InboxProject: true},
+		},
+		Sections: []todoist.Section{
+			{ID: "s1", ProjectID: "p1", Name: "doing"},
+			{ID: "s2", ProjectID: "p1", Name: "Review"},
+		},
+		Tasks: []todoist.Task{
+			{
+				ID: "t2", ProjectID: "p1", ParentID: "t1", SectionID: "s2", Content: "Draft", Priority: 2,
+				Due: &todoist.Due{Date: "2024-01-15T09:00:00", String: "every! day", IsRecurring: true},
+			},
+			{
+				ID: "t1", ProjectID: "p1", SectionID: "s1", Content: "Report", Description: "Q1",
+				Priority: todoist.PriorityP1, Labels: []string{"office"}, Checked: true,
+				Responsible: "42", Due: &todoist.Due{Date: "2024-01-15"},
+			},
+			{
+				ID: "t3", ProjectID: "p2", Content: "Call", Priority: todoist.PriorityP4,
+				Due: &todoist.Due{Date: "2024-01-15T08:00:00Z", Timezone: "Europe/Berlin"},
+			},
+			{ID: "t4", ProjectID: "p9", Content: "Orphan"},
+		},
+		Comments: []todoist.Comment{
+			{ID: "c1", TaskID: "t1", Content: "See wiki", Attachment: json.RawMessage(`{"file_name": "a.pdf"}`)},
+		},
+	}
+}
+
+func TestImport(t *testing.T) {
+	account := &fakeAccount{columns: map[string][]ticktick.Column{
+		"new-Work": {{ID: "col-doing", Name: "Doing"}},
+	}}
+	client := account.client(t)
+
+	report, err := todoist.Import(context.Background(), client, testExport(), todoist.ImportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(account.projects) != 1 || *account.projects[0].ViewMode != ticktick.ViewModeKanban ||
+		*account.projects[0].Color != "#4073FF" {
+		t.Errorf("unexpected projects: %+v", account.projects)
+	}
+
+	if report.Projects["p1"] != "new-Work" || report.Projects["p2"] != "inbox7" {
+		t.Errorf("unexpected project mapping: %v", report.Projects)
+	}
+
+	if len(account.tasks) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(account.tasks))
+	}
+
+	parent, child, call := account.tasks[0], account.tasks[1], account.tasks[2]
+
+	if parent.Title != "Report" || *parent.Priority != ticktick.PriorityHigh || *parent.Content != "Q1\n\nSee wiki" ||
+		*parent.ColumnID != "col-doing" || !*parent.IsAllDay || parent.Tags[0] != "office" {
+		t.Errorf("unexpected parent: %+v", parent)
+	}
+
+	if *child.ParentID != "new-Report" || child.ColumnID != nil || *child.Priority != ticktick.PriorityLow ||
+		*child.RepeatFlag != "RRULE:FREQ=DAILY;INTERVAL=1" || !child.StartDate.Equal(child.DueDate.Time) {
+		t.Errorf("unexpected child: %+v", child)
+	}
+
+	if call.ProjectID != "inbox7" || *call.TimeZone != "Europe/Berlin" || call.DueDate.Hour() != 9 {
+		t.Errorf("unexpected inbox task: %+v", call)
+	}
+
+	if strings.Join(account.completed, "|") != "/open/v1/project/new-Work/task/new-Report/complete" {
+		t.Errorf("unexpected completions: %v", account.completed)
+	}
+
+	var fields []string
+	for _, u := range report.Unmapped {
+		fields = append(fields, u.Kind+":"+u.Field)
+	}
+
+	want := "project:parent_id section:name comment:file_attachment task:responsible_uid " +
+		"task:due.string task:project_id"
+	if strings.Join(fields, " ") != want {
+		t.Errorf("expected unmapped %s, got %s", want, strings.Join(fields, " "))
+	}
+}
+
+func TestImportFailedSubtask(t *testing.T) {
+	account := &fakeAccount{failTitle: "Bad"}
+	client := account.client(t)
+
+	export := &todoist.Export{
+		Projects: []todoist.Project{{ID: "p1", Name: "Work"}},
+		Tasks: []todoist.Task{
+			{ID: "t1", ProjectID: "p1", Content: "Parent"},
+			{ID: "t2", ProjectID: "p1", ParentID: "t1", Content: "Bad", ChildOrder: 1},
+			{ID: "t3", ProjectID: "p1", ParentID: "t1", Content: "Good", ChildOrder: 2},
+		},
+	}
+
+	_, err := todoist.Import(context.Background(), client, export, todoist.ImportOptions{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if len(account.tasks) != 1 || account.tasks[0].Title != "Parent" {
+		t.Errorf("expected the import to stop at the failed subtask, got %+v", account.tasks)
+	}
+}
+
+func TestImportMappedProject(t *testing.T) {
+	account := &fakeAccount{}
+	client := account.client(t)
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	export := &todoist.Export{
+		Projects: []todoist.Project{{ID: "p1", Name: "Work"}},
+		Tasks: []todoist.Task{
+			{ID: "t1", ProjectID: "p1", Content: "Sync", Due: &todoist.Due{Date: "2024-01-15T09:00:00"}},
+			{ID: "t2", ProjectID: "p1", Content: "Soon", Due: &todoist.Due{String: "next week"}},
+		},
+	}
+
+	report, err := todoist.Import(context.Background(), client, export, todoist.ImportOptions{
+		Projects: map[string]string{"Work": "existing"},
+		Location: berlin,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(account.projects) != 0 || account.tasks[0].ProjectID != "existing" {
+		t.Errorf("expected tasks in the existing project, got %+v", account.tasks)
+	}
+
+	if task := account.tasks[0]; *task.TimeZone != "Europe/Berlin" || task.DueDate.UTC().Hour() != 8 {
+		t.Errorf("expected a floating time in Europe/Berlin, got %v %s", task.DueDate, *task.TimeZone)
+	}
+
+	if len(report.Unmapped) != 1 || report.Unmapped[0].Value != "next week" {
+		t.Errorf("unexpected unmapped: %v", report.Unmapped)
+	}
+}
+
+func TestImportLocalLocation(t *testing.T) {
+	account := &fakeAccount{}
+	client := account.client(t)
+
+	export := &todoist.Export{
+		Projects: []todoist.Project{{ID: "p1", Name: "Work"}},
+		Tasks: []todoist.Task{
+			{ID: "t1", ProjectID: "p1", Content: "Sync", Due: &todoist.Due{Date: "2024-01-15T09:00:00"}},
+			{ID: "t2", ProjectID: "p1", Content: "Plan", Due: &todoist.Due{Date: "2024-01-16"}},
+		},
+	}
+
+	_, err := todoist.Import(context.Background(), client, export, todoist.ImportOptions{Location: time.Local})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, task := range account.tasks {
+		if task.TimeZone != nil {
+			t.Errorf("expected no time zone for %q, got %q", task.Title, *task.TimeZone)
+		}
+	}
+}
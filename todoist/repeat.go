@@ -0,0 +1,148 @@
+package todoist
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Limits of the day-of-month and weekday forms understood by [RepeatFlag].
+const (
+	maxMonthDay  = 31
+	minDayPrefix = 2
+)
+
+// RepeatFlag converts a Todoist recurring due string, such as "every day",
+// "every other week", "every 3 months", "every mon, fri", "every weekday" or
+// "every 15th", into a TickTick repeat rule like "RRULE:FREQ=DAILY;INTERVAL=1".
+// A time of day ("at 9am") and a starting date ("starting jan 5") are
+// ignored, as they belong to the due date. Strings that repeat after
+// completion ("every! week") map to the same rule; TickTick repeats them on
+// schedule. It reports false for strings it does not understand.
+func RepeatFlag(s string) (string, bool) {
+	s = strings.Join(strings.Fields(strings.ToLower(s)), " ")
+
+	switch s {
+	case "daily":
+		s = "every day"
+	case "weekly":
+		s = "every week"
+	case "monthly":
+		s = "every month"
+	case "yearly", "annually":
+		s = "every year"
+	}
+
+	rest, ok := strings.CutPrefix(s, "every ")
+	if !ok {
+		rest, ok = strings.CutPrefix(s, "every! ")
+	}
+
+	if !ok {
+		return "", false
+	}
+
+	rest = trimClauses(rest)
+	interval := 1
+
+	if after, ok := strings.CutPrefix(rest, "other "); ok {
+		interval, rest = 2, after
+	} else if n, after, ok := strings.Cut(rest, " "); ok {
+		if v, err := strconv.Atoi(n); err == nil && v > 0 {
+			interval, rest = v, after
+		}
+	}
+
+	switch strings.TrimSuffix(rest, "s") {
+	case "day":
+		return rule("DAILY", interval, ""), true
+	case "week":
+		return rule("WEEKLY", interval, ""), true
+	case "month":
+		return rule("MONTHLY", interval, ""), true
+	case "year":
+		return rule("YEARLY", interval, ""), true
+	case "weekday", "workday":
+		return rule("WEEKLY", interval, "BYDAY=MO,TU,WE,TH,FR"), true
+	case "weekend":
+		return rule("WEEKLY", interval, "BYDAY=SA,SU"), true
+	}
+
+	if days, ok := weekdays(rest); ok {
+		return rule("WEEKLY", interval, "BYDAY="+days), true
+	}
+
+	if day, ok := monthDay(rest); ok && interval == 1 {
+		return rule("MONTHLY", interval, "BYMONTHDAY="+day), true
+	}
+
+	return "", false
+}
+
+func rule(freq string, interval int, extra string) string {
+	r := "RRULE:FREQ=" + freq + ";INTERVAL=" + strconv.Itoa(interval)
+	if extra != "" {
+		r += ";" + extra
+	}
+
+	return r
+}
+
+// trimClauses removes the time of day and starting date from a recurrence.
+func trimClauses(s string) string {
+	for _, sep := range []string{" at ", " starting ", " from "} {
+		s, _, _ = strings.Cut(s, sep)
+	}
+
+	return s
+}
+
+// weekdays converts a list of day names like "mon, wed and fri" to "MO,WE,FR".
+func weekdays(s string) (string, bool) {
+	var codes []string
+
+	for token := range strings.SplitSeq(strings.ReplaceAll(s, " and ", ","), ",") {
+		code, ok := weekday(strings.TrimSpace(token))
+		if !ok {
+			return "", false
+		}
+
+		codes = append(codes, code)
+	}
+
+	return strings.Join(codes, ","), len(codes) > 0
+}
+
+func weekday(name string) (string, bool) {
+	days := []struct{ name, code string }{
+		{"monday", "MO"}, {"tuesday", "TU"}, {"wednesday", "WE"}, {"thursday", "TH"},
+		{"friday", "FR"}, {"saturday", "SA"}, {"sunday", "SU"},
+	}
+
+	for _, d := range days {
+		if len(name) >= minDayPrefix && strings.HasPrefix(d.name, name) {
+			return d.code, true
+		}
+	}
+
+	return "", false
+}
+
+// monthDay converts "15th" to "15" and "last day" to "-1".
+func monthDay(s string) (string, bool) {
+	if s == "last day" {
+		return "-1", true
+	}
+
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		n, ok := strings.CutSuffix(s, suffix)
+		if !ok {
+			continue
+		}
+
+		if day, err := strconv.Atoi(n); err == nil && day >= 1 && day <= maxMonthDay {
+			return n, true
+		}
+	}
+
+	return "", false
+}
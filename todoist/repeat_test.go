@@ -0,0 +1,39 @@
+package todoist_test
+
+import (
+	"testing"
+
+	"github.com/slavkluev/go-ticktick/todoist"
+)
+
+func TestRepeatFlag(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"every day", "RRULE:FREQ=DAILY;INTERVAL=1"},
+		{"Daily", "RRULE:FREQ=DAILY;INTERVAL=1"},
+		{"every other week", "RRULE:FREQ=WEEKLY;INTERVAL=2"},
+		{"every 3 months", "RRULE:FREQ=MONTHLY;INTERVAL=3"},
+		{"every year at 9am", "RRULE:FREQ=YEARLY;INTERVAL=1"},
+		{"annually", "RRULE:FREQ=YEARLY;INTERVAL=1"},
+		{"every weekday", "RRULE:FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TU,WE,TH,FR"},
+		{"every weekend", "RRULE:FREQ=WEEKLY;INTERVAL=1;BYDAY=SA,SU"},
+		{"every mon, wed and Friday", "RRULE:FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,WE,FR"},
+		{"every other tues starting jan 5", "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU"},
+		{"every 15th", "RRULE:FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=15"},
+		{"every last day", "RRULE:FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=-1"},
+		{"every! 2 weeks", "RRULE:FREQ=WEEKLY;INTERVAL=2"},
+		{"every 2 hours", ""},
+		{"every 32nd", ""},
+		{"every mon, blursday", ""},
+		{"tomorrow", ""},
+	}
+
+	for _, tt := range tests {
+		got, ok := todoist.RepeatFlag(tt.in)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("%q: expected %q, got %q (%v)", tt.in, tt.want, got, ok)
+		}
+	}
+}
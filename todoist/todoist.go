@@ -0,0 +1,209 @@
+// Package todoist migrates Todoist exports to TickTick.
+//
+// [ReadJSON] reads a Todoist JSON backup, as produced by the Sync or REST
+// API, and [ReadCSV] reads the CSV export of a single project. Both return an
+// [Export], which [Import] recreates through a [ticktick.Client]: projects
+// become projects, sections kanban columns, labels tags, descriptions and
+// comments task content, and recurring due dates repeat rules where
+// [RepeatFlag] understands them. Whatever cannot be carried over is listed
+// in the [Report].
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Todoist priorities as used by its API: 4 is the UI's p1 (urgent) and 1 the
+// UI's p4 (no priority).
+const (
+	PriorityP1 = 4
+	PriorityP2 = 3
+	PriorityP3 = 2
+	PriorityP4 = 1
+)
+
+// Export is the content of a Todoist export.
+type Export struct {
+	Projects []Project
+	Sections []Section
+	Tasks    []Task
+	Comments []Comment
+}
+
+// ID is a Todoist object ID. Older exports use numbers, newer ones strings;
+// both are read into a string.
+type ID string
+
+// UnmarshalJSON accepts a JSON string, number or null.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*id = ""
+
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*id = ID(s)
+
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("todoist: invalid ID %s: %w", data, err)
+	}
+
+	*id = ID(n.String())
+
+	return nil
+}
+
+// Project is a Todoist project.
+type Project struct {
+	ID           ID     `json:"id"`
+	Name         string `json:"name"`
+	Color        string `json:"color"`
+	ParentID     ID     `json:"parent_id"`
+	ViewStyle    string `json:"view_style"`
+	ChildOrder   int    `json:"child_order"`
+	IsArchived   bool   `json:"is_archived"`
+	InboxProject bool   `json:"inbox_project"`
+}
+
+// Section is a Todoist section, a named group of tasks within a project.
+type Section struct {
+	ID           ID     `json:"id"`
+	ProjectID    ID     `json:"project_id"`
+	Name         string `json:"name"`
+	SectionOrder int    `json:"section_order"`
+}
+
+// Task is a Todoist task.
+type Task struct {
+	ID          ID     `json:"id"`
+	ProjectID   ID     `json:"project_id"`
+	SectionID   ID     `json:"section_id"`
+	ParentID    ID     `json:"parent_id"`
+	Content     string `json:"content"`
+	Description string `json:"description"`
+	// Priority uses the API scale; see [PriorityP1].
+	Priority   int       `json:"priority"`
+	Due        *Due      `json:"due"`
+	Deadline   *Deadline `json:"deadline"`
+	Duration   *Duration `json:"duration"`
+	Labels     []string  `json:"labels"`
+	Checked    bool      `json:"checked"`
+	ChildOrder int       `json:"child_order"`
+	// Responsible is the user the task is assigned to.
+	Responsible ID `json:"responsible_uid"`
+}
+
+// Due is the due date of a Todoist task. Date is "2006-01-02" for a date
+// without time, "2006-01-02T15:04:05" for a floating time and
+// "2006-01-02T15:04:05Z" for a time in Timezone. String is the date as the
+// user typed it, e.g. "every monday at 9am".
+type Due struct {
+	Date        string `json:"date"`
+	String      string `json:"string"`
+	IsRecurring bool   `json:"is_recurring"`
+	Timezone    string `json:"timezone"`
+	Lang        string `json:"lang"`
+}
+
+// Deadline is the deadline of a Todoist task, a date in the "2006-01-02" format.
+type Deadline struct {
+	Date string `json:"date"`
+}
+
+// Duration is the time a Todoist task is expected to take.
+type Duration struct {
+	Amount int    `json:"amount"`
+	Unit   string `json:"unit"`
+}
+
+// String returns the duration as e.g. "30 minute".
+func (d *Duration) String() string {
+	return strconv.Itoa(d.Amount) + " " + d.Unit
+}
+
+// Comment is a comment (a "note" in the Sync API) on a Todoist task.
+type Comment struct {
+	ID      ID     `json:"id"`
+	TaskID  ID     `json:"item_id"`
+	Content string `json:"content"`
+	// Attachment is the attached file, if any. It is not imported.
+	Attachment json.RawMessage `json:"file_attachment"`
+}
+
+// ReadJSON reads a Todoist JSON export. Both the Sync API layout, with
+// "items" and "notes", and the REST API layout, with "tasks" and "comments",
+// are accepted.
+func ReadJSON(r io.Reader) (*Export, error) {
+	var raw struct {
+		Projects []Project `json:"projects"`
+		Sections []Section `json:"sections"`
+		Items    []Task    `json:"items"`
+		Tasks    []Task    `json:"tasks"`
+		Notes    []Comment `json:"notes"`
+		Comments []struct {
+			Comment
+
+			TaskID ID `json:"task_id"`
+		} `json:"comments"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("todoist: read export: %w", err)
+	}
+
+	export := &Export{
+		Projects: raw.Projects,
+		Sections: raw.Sections,
+		Tasks:    append(raw.Items, raw.Tasks...),
+		Comments: raw.Notes,
+	}
+
+	for _, c := range raw.Comments {
+		if c.Comment.TaskID == "" {
+			c.Comment.TaskID = c.TaskID
+		}
+
+		export.Comments = append(export.Comments, c.Comment)
+	}
+
+	return export, nil
+}
+
+// Color converts a Todoist color name, such as "berry_red", to the "#RRGGBB"
+// form TickTick uses. It reports false for unknown names.
+func Color(name string) (string, bool) {
+	hex := map[string]string{
+		"berry_red":   "#B8256F",
+		"red":         "#DB4035",
+		"orange":      "#FF9933",
+		"yellow":      "#FAD000",
+		"olive_green": "#AFB83B",
+		"lime_green":  "#7ECC49",
+		"green":       "#299438",
+		"mint_green":  "#6ACCBC",
+		"teal":        "#158FAD",
+		"sky_blue":    "#14AAF5",
+		"light_blue":  "#96C3EB",
+		"blue":        "#4073FF",
+		"grape":       "#884DFF",
+		"violet":      "#AF38EB",
+		"lavender":    "#EB96EB",
+		"magenta":     "#E05194",
+		"salmon":      "#FF8D85",
+		"charcoal":    "#808080",
+		"grey":        "#B8B8B8",
+		"taupe":       "#CCAC93",
+	}[name]
+
+	return hex, hex != ""
+}
@@ -0,0 +1,69 @@
+package todoist_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick/todoist"
+)
+
+func TestReadJSON(t *testing.T) {
+	const data = `{
+		"projects": [{"id": 2203306141, "name": "Inbox", "inbox_project": true, "color": "grey"}],
+		"sections": [{"id": "7025", "project_id": "2203306141", "name": "Doing"}],
+		"items": [{"id": "1", "project_id": "2203306141", "section_id": null, "content": "Buy milk",
+			"priority": 4, "labels": ["errands"], "checked": true,
+			"due": {"date": "2024-01-15", "string": "Jan 15", "is_recurring": false}}],
+		"notes": [{"id": "n1", "item_id": "1", "content": "Oat"}],
+		"comments": [{"id": "c1", "task_id": "1", "content": "Or soy"}]
+	}`
+
+	export, err := todoist.ReadJSON(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(export.Projects) != 1 || export.Projects[0].ID != "2203306141" || !export.Projects[0].InboxProject {
+		t.Errorf("unexpected projects: %+v", export.Projects)
+	}
+
+	if len(export.Sections) != 1 || export.Sections[0].ProjectID != "2203306141" {
+		t.Errorf("unexpected sections: %+v", export.Sections)
+	}
+
+	task := export.Tasks[0]
+	if task.SectionID != "" || task.Priority != todoist.PriorityP1 || !task.Checked || task.Due.Date != "2024-01-15" {
+		t.Errorf("unexpected task: %+v", task)
+	}
+
+	if len(export.Comments) != 2 || export.Comments[0].TaskID != "1" || export.Comments[1].TaskID != "1" {
+		t.Errorf("unexpected comments: %+v", export.Comments)
+	}
+}
+
+func TestReadJSONRESTLayout(t *testing.T) {
+	const data = `{"tasks": [{"id": "1", "content": "A"}, {"id": "2", "content": "B"}]}`
+
+	export, err := todoist.ReadJSON(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(export.Tasks) != 2 {
+		t.Errorf("expected 2 tasks, got %d", len(export.Tasks))
+	}
+
+	if _, err := todoist.ReadJSON(strings.NewReader(`{"tasks": [{"id": true}]}`)); err == nil {
+		t.Error("expected an error for an invalid ID")
+	}
+}
+
+func TestColor(t *testing.T) {
+	if hex, ok := todoist.Color("berry_red"); !ok || hex != "#B8256F" {
+		t.Errorf("expected #B8256F, got %q", hex)
+	}
+
+	if _, ok := todoist.Color("plaid"); ok {
+		t.Error("expected an unknown color")
+	}
+}
@@ -0,0 +1,194 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// DefaultBaseURL is the default base URL of the TickTick web API.
+const DefaultBaseURL = "https://api.ticktick.com"
+
+// ErrNotLoggedIn is returned by methods called before [Client.Login] or
+// without [WithToken].
+var ErrNotLoggedIn = errors.New("ticktick/v2: not logged in")
+
+// Client manages communication with the TickTick web API.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+	deviceID   string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL sets a custom base URL.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithToken sets the session token of an earlier login. See [Client.Token].
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// NewClient creates a new web API client. Call [Client.Login] or pass
+// [WithToken] before calling other methods.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		httpClient: http.DefaultClient,
+		baseURL:    DefaultBaseURL,
+		deviceID:   newDeviceID(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Login signs in with the account's username (email) and password and keeps
+// the session token for subsequent calls.
+func (c *Client) Login(ctx context.Context, username, password string) error {
+	body := struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}{username, password}
+
+	var resp struct {
+		Token string `json:"token"`
+	}
+
+	if err := c.send(ctx, http.MethodPost, "/api/v2/user/signon?wc=true&remember=true", body, &resp); err != nil {
+		return err
+	}
+
+	if resp.Token == "" {
+		return errors.New("ticktick/v2: login response has no token")
+	}
+
+	c.token = resp.Token
+
+	return nil
+}
+
+// Token returns the session token, or "" before login. It can be stored and
+// passed to [WithToken] to skip logging in again.
+func (c *Client) Token() string {
+	return c.token
+}
+
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	return c.authorized(ctx, http.MethodGet, path, nil, v)
+}
+
+func (c *Client) post(ctx context.Context, path string, body, v any) error {
+	return c.authorized(ctx, http.MethodPost, path, body, v)
+}
+
+func (c *Client) put(ctx context.Context, path string, body, v any) error {
+	return c.authorized(ctx, http.MethodPut, path, body, v)
+}
+
+func (c *Client) delete(ctx context.Context, path string) error {
+	return c.authorized(ctx, http.MethodDelete, path, nil, nil)
+}
+
+func (c *Client) authorized(ctx context.Context, method, path string, body, v any) error {
+	if c.token == "" {
+		return ErrNotLoggedIn
+	}
+
+	return c.send(ctx, method, path, body, v)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, body, v any) error {
+	var reqBody io.Reader
+
+	if body != nil {
+		var buf bytes.Buffer
+
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return err
+		}
+
+		reqBody = &buf
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// The web API rejects requests that do not identify a device.
+	req.Header.Set("X-Device", fmt.Sprintf(`{"platform":"web","version":6430,"id":%q}`, c.deviceID))
+
+	if c.token != "" {
+		req.AddCookie(&http.Cookie{Name: "t", Value: c.token})
+	}
+
+	resp, err := c.httpClient.Do(req) //nolint:gosec // G704: URL is constructed from client-configured baseURL
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+
+		return &ticktick.Error{
+			StatusCode: resp.StatusCode,
+			Body:       string(respBody),
+		}
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("ticktick/v2: decode response: %w", err)
+	}
+
+	return nil
+}
+
+// newDeviceID returns a random 24-digit hex ID, the format TickTick's apps use.
+func newDeviceID() string {
+	const idBytes = 12
+
+	b := make([]byte, idBytes)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,94 @@
+package v2_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slavkluev/go-ticktick"
+	v2 "github.com/slavkluev/go-ticktick/v2"
+)
+
+// setupTestClient returns a client with a session token talking to handler.
+func setupTestClient(handler http.HandlerFunc) (*v2.Client, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	client := v2.NewClient(v2.WithBaseURL(server.URL), v2.WithToken("session"))
+
+	return client, server
+}
+
+func TestLogin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/user/signon":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+
+			if body["username"] != "user@example.com" || body["password"] != "secret" {
+				t.Errorf("unexpected credentials: %v", body)
+			}
+
+			if r.Header.Get("X-Device") == "" {
+				t.Error("expected X-Device header")
+			}
+
+			json.NewEncoder(w).Encode(map[string]string{"token": "session"})
+		case "/api/v2/habits":
+			cookie, err := r.Cookie("t")
+			if err != nil || cookie.Value != "session" {
+				t.Errorf("expected session cookie, got %v", cookie)
+			}
+
+			json.NewEncoder(w).Encode([]v2.Habit{})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := v2.NewClient(v2.WithBaseURL(server.URL))
+
+	if err := client.Login(context.Background(), "user@example.com", "secret"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.Token() != "session" {
+		t.Errorf("expected session, got %s", client.Token())
+	}
+
+	if _, err := client.Habits(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLoginError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errorCode":"username_password_not_match"}`))
+	}))
+	defer server.Close()
+
+	client := v2.NewClient(v2.WithBaseURL(server.URL))
+
+	err := client.Login(context.Background(), "user@example.com", "wrong")
+
+	var apiErr *ticktick.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected HTTP 401 error, got %v", err)
+	}
+
+	if client.Token() != "" {
+		t.Errorf("expected no token, got %s", client.Token())
+	}
+}
+
+func TestNotLoggedIn(t *testing.T) {
+	client := v2.NewClient(v2.WithBaseURL("http://127.0.0.1:0"))
+
+	if _, err := client.Habits(context.Background()); !errors.Is(err, v2.ErrNotLoggedIn) {
+		t.Fatalf("expected ErrNotLoggedIn, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2026 Viacheslav Kliuev
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+/*
+Package v2 is an experimental client for TickTick's unofficial web API.
+
+# Stability
+
+The web API is undocumented and used by TickTick's own apps. It can change or
+disappear without notice, may be rate limited or blocked, and logging in with
+a password may be against the terms of service for some accounts. This
+package is not covered by the compatibility promise of the ticktick package:
+its types and methods may change in any release. Prefer the official Open API
+in the parent package wherever it covers your needs.
+
+# Usage
+
+The web API authenticates with a session cookie obtained by logging in with
+the account's username and password:
+
+	client := v2.NewClient()
+
+	if err := client.Login(ctx, "user@example.com", "password"); err != nil {
+		log.Fatal(err)
+	}
+
+	// Reuse the session later without logging in again.
+	token := client.Token()
+	client = v2.NewClient(v2.WithToken(token))
+
+It covers what the Open API does not expose: tags, folders and filters (see
+[Client.Sync]), habits and check-ins, and focus (pomodoro) records.
+
+Errors from the API are returned as [*ticktick.Error], as in the Open API client.
+*/
+package v2
@@ -0,0 +1,48 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// Pomodoro is a recorded focus session.
+type Pomodoro struct {
+	ID        string        `json:"id"`
+	StartTime ticktick.Time `json:"startTime"`
+	EndTime   ticktick.Time `json:"endTime"`
+	Status    int           `json:"status"`
+	// PauseDuration is the time spent paused, in seconds.
+	PauseDuration int64          `json:"pauseDuration"`
+	Note          string         `json:"note"`
+	Tasks         []PomodoroTask `json:"tasks"`
+}
+
+// PomodoroTask is a task worked on during a focus session.
+type PomodoroTask struct {
+	TaskID      string        `json:"taskId"`
+	Title       string        `json:"title"`
+	ProjectName string        `json:"projectName"`
+	StartTime   ticktick.Time `json:"startTime"`
+	EndTime     ticktick.Time `json:"endTime"`
+}
+
+// Duration returns the time spent focused, excluding pauses.
+func (p *Pomodoro) Duration() time.Duration {
+	return p.EndTime.Sub(p.StartTime.Time) - time.Duration(p.PauseDuration)*time.Second
+}
+
+// Pomodoros returns the focus sessions that started between from and to.
+func (c *Client) Pomodoros(ctx context.Context, from, to time.Time) ([]Pomodoro, error) {
+	path := fmt.Sprintf("/api/v2/pomodoros?from=%d&to=%d", from.UnixMilli(), to.UnixMilli())
+
+	var pomodoros []Pomodoro
+
+	if err := c.get(ctx, path, &pomodoros); err != nil {
+		return nil, err
+	}
+
+	return pomodoros, nil
+}
@@ -0,0 +1,45 @@
+package v2_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPomodoros(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/pomodoros" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("from") != "1704067200000" || r.URL.Query().Get("to") != "1704153600000" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+
+		w.Write([]byte(`[{
+			"id": "f1",
+			"startTime": "2024-01-01T09:00:00.000+0000",
+			"endTime": "2024-01-01T09:30:00.000+0000",
+			"pauseDuration": 300,
+			"tasks": [{"taskId": "t1", "title": "Write report", "projectName": "Work"}]
+		}]`))
+	})
+	defer server.Close()
+
+	pomodoros, err := client.Pomodoros(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pomodoros) != 1 || len(pomodoros[0].Tasks) != 1 || pomodoros[0].Tasks[0].TaskID != "t1" {
+		t.Fatalf("unexpected pomodoros: %+v", pomodoros)
+	}
+
+	if d := pomodoros[0].Duration(); d != 25*time.Minute {
+		t.Errorf("expected 25m, got %v", d)
+	}
+}
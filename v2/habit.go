@@ -0,0 +1,111 @@
+package v2
+
+import (
+	"context"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// Habit status values.
+const (
+	HabitStatusActive   = 0
+	HabitStatusArchived = 1
+)
+
+// Habit check-in status values.
+const (
+	CheckinStatusUnmarked  = 0
+	CheckinStatusFailed    = 1
+	CheckinStatusCompleted = 2
+)
+
+// Habit type values.
+const (
+	// HabitTypeBoolean habits are either done or not on a given day.
+	HabitTypeBoolean = "Boolean"
+	// HabitTypeReal habits track an amount towards Goal, e.g. glasses of water.
+	HabitTypeReal = "Real"
+)
+
+// Check-in stamps encode a day as the number YYYYMMDD.
+const (
+	stampYear  = 10000
+	stampMonth = 100
+)
+
+// Habit is a recurring habit tracked with daily check-ins.
+type Habit struct {
+	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	IconRes       string        `json:"iconRes"`
+	Color         string        `json:"color"`
+	SortOrder     int64         `json:"sortOrder"`
+	Status        int           `json:"status"`
+	Encouragement string        `json:"encouragement"`
+	Type          string        `json:"type"`
+	Goal          float64       `json:"goal"`
+	Step          float64       `json:"step"`
+	Unit          string        `json:"unit"`
+	RepeatRule    string        `json:"repeatRule"`
+	Reminders     []string      `json:"reminders"`
+	TotalCheckIns int           `json:"totalCheckIns"`
+	CreatedTime   ticktick.Time `json:"createdTime"`
+	ModifiedTime  ticktick.Time `json:"modifiedTime"`
+}
+
+// HabitCheckin records a habit's progress on one day.
+type HabitCheckin struct {
+	ID      string `json:"id"`
+	HabitID string `json:"habitId"`
+	// CheckinStamp is the day as a YYYYMMDD number, e.g. 20240115.
+	CheckinStamp int           `json:"checkinStamp"`
+	CheckinTime  ticktick.Time `json:"checkinTime"`
+	Value        float64       `json:"value"`
+	Goal         float64       `json:"goal"`
+	Status       int           `json:"status"`
+}
+
+// Date returns the day of the check-in.
+func (h *HabitCheckin) Date() ticktick.Date {
+	return ticktick.Date{
+		Year:  h.CheckinStamp / stampYear,
+		Month: time.Month(h.CheckinStamp % stampYear / stampMonth),
+		Day:   h.CheckinStamp % stampMonth,
+	}
+}
+
+// Habits returns the account's habits, including archived ones.
+func (c *Client) Habits(ctx context.Context) ([]Habit, error) {
+	var habits []Habit
+
+	if err := c.get(ctx, "/api/v2/habits", &habits); err != nil {
+		return nil, err
+	}
+
+	return habits, nil
+}
+
+// HabitCheckins returns the check-ins of the given habits made after the day
+// since, keyed by habit ID.
+func (c *Client) HabitCheckins(ctx context.Context, habitIDs []string, since ticktick.Date) (
+	map[string][]HabitCheckin, error,
+) {
+	body := struct {
+		HabitIDs   []string `json:"habitIds"`
+		AfterStamp int      `json:"afterStamp"`
+	}{
+		HabitIDs:   habitIDs,
+		AfterStamp: since.Year*stampYear + int(since.Month)*stampMonth + since.Day,
+	}
+
+	var resp struct {
+		Checkins map[string][]HabitCheckin `json:"checkins"`
+	}
+
+	if err := c.post(ctx, "/api/v2/habitCheckins/query", body, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Checkins, nil
+}
@@ -0,0 +1,65 @@
+package v2_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+	v2 "github.com/slavkluev/go-ticktick/v2"
+)
+
+func TestHabits(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v2/habits" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Write([]byte(`[{"id": "h1", "name": "Read", "type": "Real", "goal": 30, "unit": "Pages", "status": 0}]`))
+	})
+	defer server.Close()
+
+	habits, err := client.Habits(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(habits) != 1 || habits[0].Type != v2.HabitTypeReal || habits[0].Goal != 30 {
+		t.Errorf("unexpected habits: %+v", habits)
+	}
+}
+
+func TestHabitCheckins(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			HabitIDs   []string `json:"habitIds"`
+			AfterStamp int      `json:"afterStamp"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		if body.AfterStamp != 20240101 || len(body.HabitIDs) != 1 || body.HabitIDs[0] != "h1" {
+			t.Errorf("unexpected body: %+v", body)
+		}
+
+		w.Write([]byte(`{"checkins": {"h1": [{"id": "c1", "habitId": "h1", "checkinStamp": 20240115, "status": 2}]}}`))
+	})
+	defer server.Close()
+
+	since := ticktick.Date{Year: 2024, Month: time.January, Day: 1}
+
+	checkins, err := client.HabitCheckins(context.Background(), []string{"h1"}, since)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := checkins["h1"]
+	if len(got) != 1 || got[0].Status != v2.CheckinStatusCompleted {
+		t.Fatalf("unexpected checkins: %+v", checkins)
+	}
+
+	if day := got[0].Date(); day.String() != "2024-01-15" {
+		t.Errorf("expected 2024-01-15, got %s", day)
+	}
+}
@@ -0,0 +1,86 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+// Folder is a group of projects, called a project group by the API. Projects
+// refer to it through [ticktick.Project.GroupID].
+type Folder struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	SortOrder int64  `json:"sortOrder"`
+	ShowAll   bool   `json:"showAll"`
+}
+
+// Filter is a saved smart list.
+type Filter struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	SortOrder int64  `json:"sortOrder"`
+	SortType  string `json:"sortType"`
+	ViewMode  string `json:"viewMode"`
+	// Rule is the filter definition, a JSON document encoded as a string.
+	Rule string `json:"rule"`
+}
+
+// SyncState is a full snapshot of the account as the web apps load it.
+type SyncState struct {
+	// InboxID is the ID of the Inbox project.
+	InboxID  string
+	Projects []ticktick.Project
+	Folders  []Folder
+	Tags     []Tag
+	Filters  []Filter
+	// Tasks holds the open tasks of every project, including the Inbox.
+	Tasks []ticktick.Task
+}
+
+// Sync retrieves a full snapshot of the account.
+func (c *Client) Sync(ctx context.Context) (*SyncState, error) {
+	var resp struct {
+		InboxID         string             `json:"inboxId"`
+		ProjectProfiles []ticktick.Project `json:"projectProfiles"`
+		ProjectGroups   []Folder           `json:"projectGroups"`
+		Tags            []Tag              `json:"tags"`
+		Filters         []Filter           `json:"filters"`
+		SyncTaskBean    struct {
+			Update []ticktick.Task `json:"update"`
+		} `json:"syncTaskBean"`
+	}
+
+	if err := c.get(ctx, "/api/v2/batch/check/0", &resp); err != nil {
+		return nil, err
+	}
+
+	return &SyncState{
+		InboxID:  resp.InboxID,
+		Projects: resp.ProjectProfiles,
+		Folders:  resp.ProjectGroups,
+		Tags:     resp.Tags,
+		Filters:  resp.Filters,
+		Tasks:    resp.SyncTaskBean.Update,
+	}, nil
+}
+
+// Folders returns the account's project folders.
+func (c *Client) Folders(ctx context.Context) ([]Folder, error) {
+	state, err := c.Sync(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.Folders, nil
+}
+
+// Filters returns the account's saved filters.
+func (c *Client) Filters(ctx context.Context) ([]Filter, error) {
+	state, err := c.Sync(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.Filters, nil
+}
@@ -0,0 +1,73 @@
+package v2_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+const syncResponse = `{
+	"inboxId": "inbox123",
+	"projectProfiles": [{"id": "p1", "name": "Work", "groupId": "g1"}],
+	"projectGroups": [{"id": "g1", "name": "Office", "sortOrder": 5}],
+	"tags": [{"name": "urgent", "label": "Urgent", "color": "#FF0000"}],
+	"filters": [{"id": "f1", "name": "Today", "rule": "{\"and\":[]}"}],
+	"syncTaskBean": {"update": [{"id": "t1", "projectId": "p1", "title": "Task", "tags": ["urgent"]}]}
+}`
+
+func TestSync(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/batch/check/0" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Write([]byte(syncResponse))
+	})
+	defer server.Close()
+
+	state, err := client.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if state.InboxID != "inbox123" {
+		t.Errorf("expected inbox123, got %s", state.InboxID)
+	}
+
+	if len(state.Projects) != 1 || state.Projects[0].GroupID != "g1" {
+		t.Errorf("unexpected projects: %+v", state.Projects)
+	}
+
+	if len(state.Folders) != 1 || state.Folders[0].Name != "Office" {
+		t.Errorf("unexpected folders: %+v", state.Folders)
+	}
+
+	if len(state.Tags) != 1 || state.Tags[0].Label != "Urgent" {
+		t.Errorf("unexpected tags: %+v", state.Tags)
+	}
+
+	if len(state.Filters) != 1 || state.Filters[0].Rule != `{"and":[]}` {
+		t.Errorf("unexpected filters: %+v", state.Filters)
+	}
+
+	if len(state.Tasks) != 1 || state.Tasks[0].Tags[0] != "urgent" {
+		t.Errorf("unexpected tasks: %+v", state.Tasks)
+	}
+}
+
+func TestFoldersAndFilters(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(syncResponse))
+	})
+	defer server.Close()
+
+	folders, err := client.Folders(context.Background())
+	if err != nil || len(folders) != 1 || folders[0].ID != "g1" {
+		t.Errorf("unexpected folders: %+v, %v", folders, err)
+	}
+
+	filters, err := client.Filters(context.Background())
+	if err != nil || len(filters) != 1 || filters[0].Name != "Today" {
+		t.Errorf("unexpected filters: %+v, %v", filters, err)
+	}
+}
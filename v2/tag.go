@@ -0,0 +1,49 @@
+package v2
+
+import (
+	"context"
+	"net/url"
+)
+
+// Tag is a task tag. Tags can be nested one level deep through Parent.
+type Tag struct {
+	// Name is the lowercase identifier used in [ticktick.Task.Tags].
+	Name string `json:"name"`
+	// Label is the name as displayed, with its original capitalization.
+	Label     string `json:"label"`
+	Color     string `json:"color"`
+	SortOrder int64  `json:"sortOrder"`
+	SortType  string `json:"sortType"`
+	// Parent is the Name of the parent tag, or empty for a top-level tag.
+	Parent string `json:"parent"`
+}
+
+// Tags returns the account's tags.
+func (c *Client) Tags(ctx context.Context) ([]Tag, error) {
+	state, err := c.Sync(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.Tags, nil
+}
+
+// RenameTag renames a tag on the account and on every task that carries it.
+func (c *Client) RenameTag(ctx context.Context, name, newName string) error {
+	return c.put(ctx, "/api/v2/tag/rename", tagChange{Name: name, NewName: newName}, nil)
+}
+
+// MergeTags moves every task tagged name to the tag into and deletes name.
+func (c *Client) MergeTags(ctx context.Context, name, into string) error {
+	return c.put(ctx, "/api/v2/tag/merge", tagChange{Name: name, NewName: into}, nil)
+}
+
+// DeleteTag deletes a tag and removes it from every task.
+func (c *Client) DeleteTag(ctx context.Context, name string) error {
+	return c.delete(ctx, "/api/v2/tag?name="+url.QueryEscape(name))
+}
+
+type tagChange struct {
+	Name    string `json:"name"`
+	NewName string `json:"newName"`
+}
@@ -0,0 +1,70 @@
+package v2_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	client, server := setupTestClient(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(syncResponse))
+	})
+	defer server.Close()
+
+	tags, err := client.Tags(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tags) != 1 || tags[0].Name != "urgent" || tags[0].Color != "#FF0000" {
+		t.Errorf("unexpected tags: %+v", tags)
+	}
+}
+
+func TestRenameAndMergeTags(t *testing.T) {
+	var got []string
+
+	client, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+
+		got = append(got, r.URL.Path+" "+body["name"]+">"+body["newName"])
+	})
+	defer server.Close()
+
+	if err := client.RenameTag(context.Background(), "old", "new"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.MergeTags(context.Background(), "dup", "new"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"/api/v2/tag/rename old>new", "/api/v2/tag/merge dup>new"}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	client, server := setupTestClient(func(_ http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+
+		if r.URL.Query().Get("name") != "to do" {
+			t.Errorf("expected name=to do, got %s", r.URL.RawQuery)
+		}
+	})
+	defer server.Close()
+
+	if err := client.DeleteTag(context.Background(), "to do"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
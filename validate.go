@@ -0,0 +1,283 @@
+package ticktick
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError describes a single invalid field in a request.
+type FieldError struct {
+	// Field is the JSON name of the invalid field, e.g. "title" or "items[1].status".
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError is returned when a request fails client-side validation.
+// It lists every invalid field, not just the first one.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i := range e.Fields {
+		msgs[i] = e.Fields[i].Error()
+	}
+
+	return "ticktick: invalid request: " + strings.Join(msgs, "; ")
+}
+
+// fieldErrors collects validation failures for a single request.
+type fieldErrors []FieldError
+
+func (fe *fieldErrors) add(field, format string, args ...any) {
+	*fe = append(*fe, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (fe fieldErrors) err() error {
+	if len(fe) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Fields: fe}
+}
+
+// Validate checks the request for mistakes the API would reject.
+// It returns a [*ValidationError] listing every invalid field, or nil.
+func (r *CreateTaskRequest) Validate() error {
+	var fe fieldErrors
+
+	if r == nil {
+		fe.add("", "request must not be nil")
+
+		return fe.err()
+	}
+
+	if strings.TrimSpace(r.Title) == "" {
+		fe.add("title", "must not be empty")
+	}
+
+	if r.ProjectID == "" {
+		fe.add("projectId", "must not be empty")
+	}
+
+	validateTaskFields(&fe, taskFields{
+		startDate:  r.StartDate,
+		dueDate:    r.DueDate,
+		priority:   r.Priority,
+		reminders:  r.Reminders,
+		repeatFlag: r.RepeatFlag,
+		kind:       r.Kind,
+		items:      r.Items,
+	})
+
+	return fe.err()
+}
+
+// Validate checks the request for mistakes the API would reject.
+// It returns a [*ValidationError] listing every invalid field, or nil.
+func (r *UpdateTaskRequest) Validate() error {
+	var fe fieldErrors
+
+	if r == nil {
+		fe.add("", "request must not be nil")
+
+		return fe.err()
+	}
+
+	if r.ID == "" {
+		fe.add("id", "must not be empty")
+	}
+
+	if r.ProjectID == "" {
+		fe.add("projectId", "must not be empty")
+	}
+
+	if r.Title != nil && strings.TrimSpace(*r.Title) == "" {
+		fe.add("title", "must not be empty when set")
+	}
+
+	validateTaskFields(&fe, taskFields{
+		startDate:  r.StartDate,
+		dueDate:    r.DueDate,
+		priority:   r.Priority,
+		reminders:  r.Reminders,
+		repeatFlag: r.RepeatFlag,
+		kind:       r.Kind,
+		items:      r.Items,
+	})
+
+	return fe.err()
+}
+
+// Validate checks the subtask for mistakes the API would reject.
+// It returns a [*ValidationError] listing every invalid field, or nil.
+func (r *CreateChecklistItemRequest) Validate() error {
+	var fe fieldErrors
+
+	if r == nil {
+		fe.add("", "request must not be nil")
+
+		return fe.err()
+	}
+
+	validateChecklistItem(&fe, "", r)
+
+	return fe.err()
+}
+
+// Validate checks the request for mistakes the API would reject.
+// It returns a [*ValidationError] listing every invalid field, or nil.
+func (r *CreateProjectRequest) Validate() error {
+	var fe fieldErrors
+
+	if r == nil {
+		fe.add("", "request must not be nil")
+
+		return fe.err()
+	}
+
+	if strings.TrimSpace(r.Name) == "" {
+		fe.add("name", "must not be empty")
+	}
+
+	validateProjectFields(&fe, r.Color, r.ViewMode, r.Kind)
+
+	return fe.err()
+}
+
+// Validate checks the request for mistakes the API would reject.
+// It returns a [*ValidationError] listing every invalid field, or nil.
+func (r *UpdateProjectRequest) Validate() error {
+	var fe fieldErrors
+
+	if r == nil {
+		fe.add("", "request must not be nil")
+
+		return fe.err()
+	}
+
+	if r.Name != nil && strings.TrimSpace(*r.Name) == "" {
+		fe.add("name", "must not be empty when set")
+	}
+
+	validateProjectFields(&fe, r.Color, r.ViewMode, r.Kind)
+
+	return fe.err()
+}
+
+// taskFields holds the request fields shared by CreateTaskRequest and UpdateTaskRequest.
+type taskFields struct {
+	startDate, dueDate *Time
+	priority           *int
+	reminders          []string
+	repeatFlag         *string
+	kind               *string
+	items              []CreateChecklistItemRequest
+}
+
+func validateTaskFields(fe *fieldErrors, f taskFields) {
+	if f.startDate != nil && f.dueDate != nil && !f.startDate.IsZero() && !f.dueDate.IsZero() &&
+		f.startDate.After(f.dueDate.Time) {
+		fe.add("startDate", "must not be after dueDate")
+	}
+
+	if f.priority != nil && !validPriority(*f.priority) {
+		fe.add("priority", "must be one of %d, %d, %d, %d, got %d",
+			PriorityNone, PriorityLow, PriorityMedium, PriorityHigh, *f.priority)
+	}
+
+	for i, reminder := range f.reminders {
+		if !strings.HasPrefix(reminder, "TRIGGER:") {
+			fe.add(fmt.Sprintf("reminders[%d]", i), "must start with TRIGGER:, got %q", reminder)
+		}
+	}
+
+	if f.repeatFlag != nil && *f.repeatFlag != "" && !strings.HasPrefix(*f.repeatFlag, "RRULE:") &&
+		!strings.HasPrefix(*f.repeatFlag, "ERULE:") {
+		fe.add("repeatFlag", "must start with RRULE: or ERULE:, got %q", *f.repeatFlag)
+	}
+
+	if f.kind != nil {
+		switch *f.kind {
+		case TaskKindText, TaskKindNote, TaskKindChecklist:
+		default:
+			fe.add("kind", "must be %q, %q or %q, got %q", TaskKindText, TaskKindNote, TaskKindChecklist, *f.kind)
+		}
+	}
+
+	for i := range f.items {
+		validateChecklistItem(fe, fmt.Sprintf("items[%d].", i), &f.items[i])
+	}
+}
+
+func validateChecklistItem(fe *fieldErrors, prefix string, item *CreateChecklistItemRequest) {
+	if strings.TrimSpace(item.Title) == "" {
+		fe.add(prefix+"title", "must not be empty")
+	}
+
+	if item.Status != nil && *item.Status != ChecklistStatusNormal && *item.Status != ChecklistStatusCompleted {
+		fe.add(prefix+"status", "must be %d or %d, got %d",
+			ChecklistStatusNormal, ChecklistStatusCompleted, *item.Status)
+	}
+}
+
+func validateProjectFields(fe *fieldErrors, color, viewMode, kind *string) {
+	if color != nil && !validColor(*color) {
+		fe.add("color", "must be a hex color like #F18181, got %q", *color)
+	}
+
+	if viewMode != nil {
+		switch *viewMode {
+		case ViewModeList, ViewModeKanban, ViewModeTimeline:
+		default:
+			fe.add("viewMode", "must be %q, %q or %q, got %q",
+				ViewModeList, ViewModeKanban, ViewModeTimeline, *viewMode)
+		}
+	}
+
+	if kind != nil {
+		switch *kind {
+		case ProjectKindTask, ProjectKindNote:
+		default:
+			fe.add("kind", "must be %q or %q, got %q", ProjectKindTask, ProjectKindNote, *kind)
+		}
+	}
+}
+
+func validPriority(p int) bool {
+	switch p {
+	case PriorityNone, PriorityLow, PriorityMedium, PriorityHigh:
+		return true
+	default:
+		return false
+	}
+}
+
+// validColor reports whether s is a "#RRGGBB" hex color.
+func validColor(s string) bool {
+	const hexColorLen = 7
+
+	if len(s) != hexColorLen || s[0] != '#' {
+		return false
+	}
+
+	for _, c := range s[1:] {
+		isDigit := c >= '0' && c <= '9'
+		isHex := (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+
+		if !isDigit && !isHex {
+			return false
+		}
+	}
+
+	return true
+}
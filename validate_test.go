@@ -0,0 +1,221 @@
+package ticktick_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slavkluev/go-ticktick"
+)
+
+func fieldNames(t *testing.T, err error) []string {
+	t.Helper()
+
+	var vErr *ticktick.ValidationError
+
+	if !errors.As(err, &vErr) {
+		t.Fatalf("expected *ticktick.ValidationError, got %T: %v", err, err)
+	}
+
+	names := make([]string, len(vErr.Fields))
+	for i, f := range vErr.Fields {
+		names[i] = f.Field
+	}
+
+	return names
+}
+
+func TestCreateTaskRequestValidate(t *testing.T) {
+	start := time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		req    *ticktick.CreateTaskRequest
+		fields []string
+	}{
+		{
+			name: "valid",
+			req:  &ticktick.CreateTaskRequest{Title: "Task", ProjectID: "proj1"},
+		},
+		{
+			name:   "nil request",
+			req:    nil,
+			fields: []string{""},
+		},
+		{
+			name:   "missing title and project",
+			req:    &ticktick.CreateTaskRequest{Title: "  "},
+			fields: []string{"title", "projectId"},
+		},
+		{
+			name: "start after due",
+			req: &ticktick.CreateTaskRequest{
+				Title:     "Task",
+				ProjectID: "proj1",
+				StartDate: ticktick.NewTime(start),
+				DueDate:   ticktick.NewTime(due),
+			},
+			fields: []string{"startDate"},
+		},
+		{
+			name: "invalid priority, reminder, repeat, kind and item",
+			req: &ticktick.CreateTaskRequest{
+				Title:      "Task",
+				ProjectID:  "proj1",
+				Priority:   ticktick.Int(2),
+				Reminders:  []string{"TRIGGER:PT0S", "PT30M"},
+				RepeatFlag: ticktick.String("FREQ=DAILY"),
+				Kind:       ticktick.String("HABIT"),
+				Items: []ticktick.CreateChecklistItemRequest{
+					{Title: "ok"},
+					{Title: "", Status: ticktick.Int(2)},
+				},
+			},
+			fields: []string{"priority", "reminders[1]", "repeatFlag", "kind", "items[1].title", "items[1].status"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+
+			if tt.fields == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			got := fieldNames(t, err)
+			if strings.Join(got, ",") != strings.Join(tt.fields, ",") {
+				t.Errorf("expected fields %v, got %v", tt.fields, got)
+			}
+		})
+	}
+}
+
+func TestUpdateTaskRequestValidate(t *testing.T) {
+	err := (&ticktick.UpdateTaskRequest{ID: "task1", ProjectID: "proj1"}).Validate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = (&ticktick.UpdateTaskRequest{Title: ticktick.String("")}).Validate()
+
+	got := fieldNames(t, err)
+	if strings.Join(got, ",") != "id,projectId,title" {
+		t.Errorf("unexpected fields: %v", got)
+	}
+}
+
+func TestChecklistItemRequestValidate(t *testing.T) {
+	if err := (&ticktick.CreateChecklistItemRequest{Title: "Item"}).Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := fieldNames(t, (&ticktick.CreateChecklistItemRequest{Status: ticktick.Int(5)}).Validate())
+	if strings.Join(got, ",") != "title,status" {
+		t.Errorf("unexpected fields: %v", got)
+	}
+}
+
+func TestProjectRequestValidate(t *testing.T) {
+	valid := &ticktick.CreateProjectRequest{
+		Name:     "Work",
+		Color:    ticktick.String("#f18181"),
+		ViewMode: ticktick.String(ticktick.ViewModeKanban),
+		Kind:     ticktick.String(ticktick.ProjectKindNote),
+	}
+
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid := &ticktick.CreateProjectRequest{
+		Color:    ticktick.String("red"),
+		ViewMode: ticktick.String("grid"),
+		Kind:     ticktick.String("HABIT"),
+	}
+
+	got := fieldNames(t, invalid.Validate())
+	if strings.Join(got, ",") != "name,color,viewMode,kind" {
+		t.Errorf("unexpected fields: %v", got)
+	}
+
+	if err := (&ticktick.UpdateProjectRequest{}).Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got = fieldNames(t, (&ticktick.UpdateProjectRequest{
+		Name:  ticktick.String(""),
+		Color: ticktick.String("#12345G"),
+	}).Validate())
+	if strings.Join(got, ",") != "name,color" {
+		t.Errorf("unexpected fields: %v", got)
+	}
+}
+
+func TestValidationErrorString(t *testing.T) {
+	err := &ticktick.ValidationError{Fields: []ticktick.FieldError{
+		{Field: "title", Message: "must not be empty"},
+		{Field: "projectId", Message: "must not be empty"},
+	}}
+
+	expected := "ticktick: invalid request: title: must not be empty; projectId: must not be empty"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestClientValidatesRequests(t *testing.T) {
+	client, server := setupTestClient(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("request should not reach the server")
+	})
+	defer server.Close()
+
+	ctx := context.Background()
+
+	if _, err := client.CreateTask(ctx, &ticktick.CreateTaskRequest{}); err == nil {
+		t.Error("expected CreateTask validation error")
+	}
+
+	if _, err := client.UpdateTask(ctx, "task1", &ticktick.UpdateTaskRequest{}); err == nil {
+		t.Error("expected UpdateTask validation error")
+	}
+
+	if _, err := client.CreateProject(ctx, &ticktick.CreateProjectRequest{}); err == nil {
+		t.Error("expected CreateProject validation error")
+	}
+
+	_, err := client.UpdateProject(ctx, "proj1", &ticktick.UpdateProjectRequest{Kind: ticktick.String("x")})
+	if err == nil {
+		t.Error("expected UpdateProject validation error")
+	}
+}
+
+func TestWithValidationDisabled(t *testing.T) {
+	var called bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+
+		w.Write([]byte(`{"id":"task1"}`))
+	}))
+	defer server.Close()
+
+	client := ticktick.NewClient("token", ticktick.WithBaseURL(server.URL), ticktick.WithValidation(false))
+
+	if _, err := client.CreateTask(context.Background(), &ticktick.CreateTaskRequest{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !called {
+		t.Error("expected request to reach the server")
+	}
+}